@@ -16,11 +16,14 @@ package analysis
 
 import (
 	"encoding/json"
+	"strconv"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 
 	"github.com/Fotkurz/horusec-devkit/pkg/enums/analysis"
+	validationUtils "github.com/Fotkurz/horusec-devkit/pkg/utils/validation"
 )
 
 //nolint:lll // notations need more than 130 characters
@@ -47,6 +50,30 @@ func (a *Analysis) GetTable() string {
 	return "analysis"
 }
 
+// Validate returns the errors of all invalid fields, including the ones of each analysis vulnerability by its index,
+// which is checked by the database service before writing it.
+func (a *Analysis) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.ID, validationUtils.NotNilUUIDRule()),
+		validation.Field(&a.WorkspaceID, validationUtils.NotNilUUIDRule()),
+		validation.Field(&a.RepositoryID, validationUtils.NotNilUUIDRule()),
+		validation.Field(&a.Status, validation.Required, validationUtils.InValuesRule(analysis.Values())),
+		validation.Field(&a.AnalysisVulnerabilities, validation.By(a.validateAnalysisVulnerabilities)),
+	)
+}
+
+func (a *Analysis) validateAnalysisVulnerabilities(_ interface{}) error {
+	errs := validation.Errors{}
+
+	for index := range a.AnalysisVulnerabilities {
+		if err := a.AnalysisVulnerabilities[index].Validate(); err != nil {
+			errs[strconv.Itoa(index)] = err
+		}
+	}
+
+	return errs.Filter()
+}
+
 func (a *Analysis) ToBytes() []byte {
 	bytes, _ := json.Marshal(a)
 
@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
@@ -34,6 +35,28 @@ func TestGetTableAnalysis(t *testing.T) {
 	})
 }
 
+func TestValidate(t *testing.T) {
+	t.Run("should return no error when valid analysis", func(t *testing.T) {
+		id := uuid.New()
+		analysis := &Analysis{ID: id, WorkspaceID: uuid.New(), RepositoryID: uuid.New(),
+			Status: analysisEnum.Running, AnalysisVulnerabilities: []AnalysisVulnerabilities{
+				getValidAnalysisVulnerabilities(id)}}
+
+		assert.NoError(t, analysis.Validate())
+	})
+
+	t.Run("should return the errors of all invalid fields and analysis vulnerabilities", func(t *testing.T) {
+		analysis := &Analysis{Status: "test", AnalysisVulnerabilities: []AnalysisVulnerabilities{
+			getValidAnalysisVulnerabilities(uuid.New()), getValidAnalysisVulnerabilities(uuid.Nil)}}
+
+		var validationErrors validation.Errors
+		assert.ErrorAs(t, analysis.Validate(), &validationErrors)
+		assert.Len(t, validationErrors, 5)
+		assert.Contains(t, validationErrors["analysisVulnerabilities"].Error(), "1: (analysisID")
+		assert.NotContains(t, validationErrors["analysisVulnerabilities"].Error(), "0:")
+	})
+}
+
 func TestToBytesAnalysis(t *testing.T) {
 	t.Run("should parse analysis to bytes", func(t *testing.T) {
 		analysis := &Analysis{}
@@ -17,9 +17,11 @@ package analysis
 import (
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 
 	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	validationUtils "github.com/Fotkurz/horusec-devkit/pkg/utils/validation"
 )
 
 //nolint:lll,revive // notations need more than 130 characters and struct used on gorm
@@ -34,6 +36,17 @@ func (a *AnalysisVulnerabilities) GetTable() string {
 	return "analysis_vulnerabilities"
 }
 
+// Validate returns the errors of all invalid fields, including the ones of the vulnerability.
+func (a *AnalysisVulnerabilities) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.VulnerabilityID, validationUtils.NotNilUUIDRule()),
+		validation.Field(&a.AnalysisID, validationUtils.NotNilUUIDRule()),
+		validation.Field(&a.Vulnerability, validation.By(func(_ interface{}) error {
+			return a.Vulnerability.Validate()
+		})),
+	)
+}
+
 func (a *AnalysisVulnerabilities) SetCreatedAt() {
 	a.CreatedAt = time.Now()
 }
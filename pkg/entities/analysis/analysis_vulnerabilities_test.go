@@ -18,12 +18,43 @@ import (
 	"testing"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/confidence"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	vulnerabilityEnum "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
 )
 
+func getValidAnalysisVulnerabilities(analysisID uuid.UUID) AnalysisVulnerabilities {
+	analysisVulnerabilities := AnalysisVulnerabilities{AnalysisID: analysisID,
+		Vulnerability: vulnerability.Vulnerability{Severity: severities.High, Confidence: confidence.High,
+			Type: vulnerabilityEnum.Vulnerability}}
+	analysisVulnerabilities.SetVulnerabilityID()
+
+	return analysisVulnerabilities
+}
+
+func TestValidateAnalysisVulnerabilities(t *testing.T) {
+	t.Run("should return no error when valid analysis vulnerabilities", func(t *testing.T) {
+		analysisVulnerabilities := getValidAnalysisVulnerabilities(uuid.New())
+
+		assert.NoError(t, analysisVulnerabilities.Validate())
+	})
+
+	t.Run("should return the errors of the vulnerability", func(t *testing.T) {
+		analysisVulnerabilities := getValidAnalysisVulnerabilities(uuid.Nil)
+		analysisVulnerabilities.Vulnerability.Severity = "test"
+
+		var validationErrors validation.Errors
+		assert.ErrorAs(t, analysisVulnerabilities.Validate(), &validationErrors)
+		assert.Contains(t, validationErrors, "analysisID")
+		assert.Contains(t, validationErrors["vulnerabilities"].Error(), "severity")
+	})
+}
+
 func TestGetTableAnalysisVulnerabilities(t *testing.T) {
 	t.Run("should success get database table name", func(t *testing.T) {
 		analysisVulnerabilities := &AnalysisVulnerabilities{}
@@ -0,0 +1,190 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+)
+
+// DiffResult contains the vulnerabilities of two analysis classified by the changes between them. New ones exist
+// only on the head analysis, fixed ones only on the base analysis, unchanged ones on both at the same location and
+// moved ones on both at different locations.
+type DiffResult struct {
+	New       []*vulnerability.Vulnerability `json:"new"`
+	Fixed     []*vulnerability.Vulnerability `json:"fixed"`
+	Unchanged []*vulnerability.Vulnerability `json:"unchanged"`
+	Moved     []*MovedVulnerability          `json:"moved"`
+	Summary   DiffSummary                    `json:"summary"`
+}
+
+// MovedVulnerability is a vulnerability found on both analysis, but on another file, line or column on the head.
+type MovedVulnerability struct {
+	Base *vulnerability.Vulnerability `json:"base"`
+	Head *vulnerability.Vulnerability `json:"head"`
+}
+
+// DiffSummary contains the total of vulnerabilities of each classification by severity. The severity of the head
+// analysis is used for unchanged and moved vulnerabilities.
+type DiffSummary struct {
+	New       map[severities.Severity]int `json:"new"`
+	Fixed     map[severities.Severity]int `json:"fixed"`
+	Unchanged map[severities.Severity]int `json:"unchanged"`
+	Moved     map[severities.Severity]int `json:"moved"`
+}
+
+// hashMatcher finds the base vulnerabilities by their current or deprecated hashes, matching each of them only once,
+// so duplicated vulnerabilities are matched one to one.
+type hashMatcher struct {
+	vulnerabilities []*vulnerability.Vulnerability
+	matched         []bool
+	indexes         map[string][]int
+}
+
+// Diff compares the vulnerabilities of the base analysis, like the last analysis of the main branch, with the ones of
+// the head analysis, like the analysis of a pull request. Vulnerabilities are the same when the VulnHash or any of
+// the DeprecatedHashes of one of them is equal to a hash of the other, so hashes generated by older versions are still
+// matched. A nil base analysis classifies all head vulnerabilities as new.
+// Usage example: if len(analysis.Diff(base, head).New) > 0 { ... }
+func Diff(base, head *Analysis) *DiffResult {
+	result := newDiffResult()
+	matcher := newHashMatcher(base)
+
+	for _, headVuln := range getVulnerabilities(head) {
+		result.add(matcher.match(headVuln), headVuln)
+	}
+
+	for _, baseVuln := range matcher.getUnmatched() {
+		result.Fixed = append(result.Fixed, baseVuln)
+		result.Summary.Fixed[baseVuln.Severity]++
+	}
+
+	return result
+}
+
+// HasNewVulnerabilities returns true when the head analysis introduced any vulnerability of the severities, or of
+// any severity when called without severities.
+func (d *DiffResult) HasNewVulnerabilities(severityList ...severities.Severity) bool {
+	if len(severityList) == 0 {
+		return len(d.New) > 0
+	}
+
+	for _, severity := range severityList {
+		if d.Summary.New[severity] > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newDiffResult() *DiffResult {
+	return &DiffResult{
+		New:       []*vulnerability.Vulnerability{},
+		Fixed:     []*vulnerability.Vulnerability{},
+		Unchanged: []*vulnerability.Vulnerability{},
+		Moved:     []*MovedVulnerability{},
+		Summary: DiffSummary{
+			New:       map[severities.Severity]int{},
+			Fixed:     map[severities.Severity]int{},
+			Unchanged: map[severities.Severity]int{},
+			Moved:     map[severities.Severity]int{},
+		},
+	}
+}
+
+func (d *DiffResult) add(baseVuln, headVuln *vulnerability.Vulnerability) {
+	if baseVuln == nil {
+		d.New = append(d.New, headVuln)
+		d.Summary.New[headVuln.Severity]++
+
+		return
+	}
+
+	if isSameLocation(baseVuln, headVuln) {
+		d.Unchanged = append(d.Unchanged, headVuln)
+		d.Summary.Unchanged[headVuln.Severity]++
+
+		return
+	}
+
+	d.Moved = append(d.Moved, &MovedVulnerability{Base: baseVuln, Head: headVuln})
+	d.Summary.Moved[headVuln.Severity]++
+}
+
+func newHashMatcher(base *Analysis) *hashMatcher {
+	matcher := &hashMatcher{vulnerabilities: getVulnerabilities(base), indexes: map[string][]int{}}
+	matcher.matched = make([]bool, len(matcher.vulnerabilities))
+
+	for index, vuln := range matcher.vulnerabilities {
+		for _, hash := range getHashes(vuln) {
+			matcher.indexes[hash] = append(matcher.indexes[hash], index)
+		}
+	}
+
+	return matcher
+}
+
+// match returns the first base vulnerability not matched yet with any hash of the vulnerability, searching by the
+// current hash first, or nil when not found.
+func (h *hashMatcher) match(vuln *vulnerability.Vulnerability) *vulnerability.Vulnerability {
+	for _, hash := range getHashes(vuln) {
+		for _, index := range h.indexes[hash] {
+			if !h.matched[index] {
+				h.matched[index] = true
+
+				return h.vulnerabilities[index]
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *hashMatcher) getUnmatched() (unmatched []*vulnerability.Vulnerability) {
+	for index, vuln := range h.vulnerabilities {
+		if !h.matched[index] {
+			unmatched = append(unmatched, vuln)
+		}
+	}
+
+	return unmatched
+}
+
+func getVulnerabilities(entity *Analysis) (vulnerabilities []*vulnerability.Vulnerability) {
+	if entity == nil {
+		return nil
+	}
+
+	for index := range entity.AnalysisVulnerabilities {
+		vulnerabilities = append(vulnerabilities, &entity.AnalysisVulnerabilities[index].Vulnerability)
+	}
+
+	return vulnerabilities
+}
+
+func getHashes(vuln *vulnerability.Vulnerability) (hashes []string) {
+	for _, hash := range append([]string{vuln.VulnHash}, vuln.DeprecatedHashes...) {
+		if hash != "" {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return hashes
+}
+
+func isSameLocation(baseVuln, headVuln *vulnerability.Vulnerability) bool {
+	return baseVuln.File == headVuln.File && baseVuln.Line == headVuln.Line && baseVuln.Column == headVuln.Column
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+)
+
+func newDiffTestAnalysis(vulnerabilities ...vulnerability.Vulnerability) *Analysis {
+	entity := &Analysis{}
+
+	for _, vuln := range vulnerabilities {
+		entity.AnalysisVulnerabilities = append(entity.AnalysisVulnerabilities,
+			AnalysisVulnerabilities{Vulnerability: vuln})
+	}
+
+	return entity
+}
+
+func TestDiff(t *testing.T) {
+	base := newDiffTestAnalysis(
+		vulnerability.Vulnerability{VulnHash: "unchanged", File: "main.go", Line: "1", Severity: severities.High},
+		vulnerability.Vulnerability{VulnHash: "moved", File: "main.go", Line: "2", Severity: severities.Low},
+		vulnerability.Vulnerability{VulnHash: "fixed", File: "api.go", Line: "3", Severity: severities.Critical},
+		vulnerability.Vulnerability{VulnHash: "old", File: "old.go", Line: "4", Severity: severities.Medium},
+	)
+
+	head := newDiffTestAnalysis(
+		vulnerability.Vulnerability{VulnHash: "unchanged", File: "main.go", Line: "1", Severity: severities.High},
+		vulnerability.Vulnerability{VulnHash: "moved", File: "main.go", Line: "20", Severity: severities.Low},
+		vulnerability.Vulnerability{VulnHash: "new", File: "api.go", Line: "3", Severity: severities.Critical},
+		vulnerability.Vulnerability{VulnHash: "current", DeprecatedHashes: []string{"old"}, File: "old.go",
+			Line: "4", Severity: severities.Medium},
+	)
+
+	t.Run("should classify the vulnerabilities by the changes", func(t *testing.T) {
+		result := Diff(base, head)
+
+		assert.Equal(t, []*vulnerability.Vulnerability{&head.AnalysisVulnerabilities[2].Vulnerability}, result.New)
+		assert.Equal(t, []*vulnerability.Vulnerability{&base.AnalysisVulnerabilities[2].Vulnerability}, result.Fixed)
+		assert.Equal(t, []*vulnerability.Vulnerability{&head.AnalysisVulnerabilities[0].Vulnerability,
+			&head.AnalysisVulnerabilities[3].Vulnerability}, result.Unchanged)
+		assert.Equal(t, []*MovedVulnerability{{Base: &base.AnalysisVulnerabilities[1].Vulnerability,
+			Head: &head.AnalysisVulnerabilities[1].Vulnerability}}, result.Moved)
+	})
+
+	t.Run("should count the vulnerabilities by severity", func(t *testing.T) {
+		result := Diff(base, head)
+
+		assert.Equal(t, map[severities.Severity]int{severities.Critical: 1}, result.Summary.New)
+		assert.Equal(t, map[severities.Severity]int{severities.Critical: 1}, result.Summary.Fixed)
+		assert.Equal(t, map[severities.Severity]int{severities.High: 1, severities.Medium: 1}, result.Summary.Unchanged)
+		assert.Equal(t, map[severities.Severity]int{severities.Low: 1}, result.Summary.Moved)
+	})
+
+	t.Run("should match the deprecated hashes of the base analysis", func(t *testing.T) {
+		result := Diff(
+			newDiffTestAnalysis(vulnerability.Vulnerability{VulnHash: "v2", DeprecatedHashes: []string{"v1"}}),
+			newDiffTestAnalysis(vulnerability.Vulnerability{VulnHash: "v1"}),
+		)
+
+		assert.Len(t, result.Unchanged, 1)
+		assert.Empty(t, result.New)
+		assert.Empty(t, result.Fixed)
+	})
+
+	t.Run("should match each duplicated vulnerability only once", func(t *testing.T) {
+		result := Diff(
+			newDiffTestAnalysis(vulnerability.Vulnerability{VulnHash: "hash"}),
+			newDiffTestAnalysis(vulnerability.Vulnerability{VulnHash: "hash"}, vulnerability.Vulnerability{VulnHash: "hash"}),
+		)
+
+		assert.Len(t, result.Unchanged, 1)
+		assert.Len(t, result.New, 1)
+	})
+
+	t.Run("should not match vulnerabilities without hash", func(t *testing.T) {
+		result := Diff(newDiffTestAnalysis(vulnerability.Vulnerability{}), newDiffTestAnalysis(vulnerability.Vulnerability{}))
+
+		assert.Len(t, result.New, 1)
+		assert.Len(t, result.Fixed, 1)
+	})
+
+	t.Run("should classify all vulnerabilities as new when nil base", func(t *testing.T) {
+		result := Diff(nil, head)
+
+		assert.Len(t, result.New, 4)
+		assert.Empty(t, result.Fixed)
+	})
+}
+
+func TestHasNewVulnerabilities(t *testing.T) {
+	result := Diff(nil, newDiffTestAnalysis(vulnerability.Vulnerability{Severity: severities.Medium}))
+
+	t.Run("should return true when any new vulnerability", func(t *testing.T) {
+		assert.True(t, result.HasNewVulnerabilities())
+	})
+
+	t.Run("should return true when new vulnerability of the severities", func(t *testing.T) {
+		assert.True(t, result.HasNewVulnerabilities(severities.High, severities.Medium))
+	})
+
+	t.Run("should return false when no new vulnerability of the severities", func(t *testing.T) {
+		assert.False(t, result.HasNewVulnerabilities(severities.Critical, severities.High))
+	})
+
+	t.Run("should return false when no new vulnerability", func(t *testing.T) {
+		assert.False(t, Diff(nil, nil).HasNewVulnerabilities())
+	})
+}
@@ -0,0 +1,201 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/languages"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+)
+
+// Counts contains the total of vulnerabilities by severity and type.
+type Counts map[severities.Severity]map[vulnerabilityEnums.Type]int
+
+// Summary contains the statistics of one or more analysis, with the totals of vulnerabilities by severity and type
+// of the whole analysis and of each language, tool, commit author and file. Summaries of different repositories or
+// time buckets could be aggregated using Merge, avoiding to recompute them from the raw analysis.
+type Summary struct {
+	TotalAnalysis int                           `json:"totalAnalysis"`
+	Total         Counts                        `json:"total"`
+	ByLanguage    map[languages.Language]Counts `json:"byLanguage"`
+	ByTool        map[tools.Tool]Counts         `json:"byTool"`
+	ByAuthor      map[string]Counts             `json:"byAuthor"`
+	ByFile        map[string]Counts             `json:"byFile"`
+}
+
+// NewSummary returns the statistics of the analysis vulnerabilities. The commit authors are identified by their
+// emails, or by their names when the email is empty, and the vulnerabilities without author or file are only
+// counted on the other groups. A nil analysis returns an empty summary.
+// Usage example: summary := analysis.NewSummary(entity), summary.Total.GetTotalBySeverity(severities.Critical)
+func NewSummary(entity *Analysis) *Summary {
+	summary := newEmptySummary()
+	if entity == nil {
+		return summary
+	}
+
+	summary.TotalAnalysis = 1
+	for _, vuln := range getVulnerabilities(entity) {
+		summary.add(vuln)
+	}
+
+	return summary
+}
+
+// MergeSummaries returns a new summary with the sum of all summaries, without changing them.
+// Usage example: summary := analysis.MergeSummaries(firstRepositorySummary, secondRepositorySummary)
+func MergeSummaries(summaries ...*Summary) *Summary {
+	merged := newEmptySummary()
+	for _, summary := range summaries {
+		merged.Merge(summary)
+	}
+
+	return merged
+}
+
+func newEmptySummary() *Summary {
+	return &Summary{
+		Total:      Counts{},
+		ByLanguage: map[languages.Language]Counts{},
+		ByTool:     map[tools.Tool]Counts{},
+		ByAuthor:   map[string]Counts{},
+		ByFile:     map[string]Counts{},
+	}
+}
+
+func (s *Summary) add(vuln *vulnerability.Vulnerability) {
+	s.Total.add(vuln.Severity, vuln.Type, 1)
+	s.ByLanguage = addGroupCount(s.ByLanguage, vuln.Language, vuln)
+	s.ByTool = addGroupCount(s.ByTool, vuln.SecurityTool, vuln)
+	s.ByAuthor = addGroupCount(s.ByAuthor, getAuthor(vuln), vuln)
+	s.ByFile = addGroupCount(s.ByFile, vuln.File, vuln)
+}
+
+func getAuthor(vuln *vulnerability.Vulnerability) string {
+	if vuln.CommitEmail != "" {
+		return vuln.CommitEmail
+	}
+
+	return vuln.CommitAuthor
+}
+
+// addGroupCount increments the counts of the group key, ignoring the empty keys.
+func addGroupCount[K comparable](groups map[K]Counts, key K, vuln *vulnerability.Vulnerability) map[K]Counts {
+	var empty K
+	if key == empty {
+		return groups
+	}
+
+	if groups == nil {
+		groups = map[K]Counts{}
+	}
+
+	if groups[key] == nil {
+		groups[key] = Counts{}
+	}
+
+	groups[key].add(vuln.Severity, vuln.Type, 1)
+
+	return groups
+}
+
+// Merge adds the totals of the other summary into this one, so it could be used to aggregate the summaries of many
+// repositories or of many analysis of the same time bucket. A nil summary is ignored.
+func (s *Summary) Merge(other *Summary) {
+	if other == nil {
+		return
+	}
+
+	s.TotalAnalysis += other.TotalAnalysis
+	s.Total = s.Total.merge(other.Total)
+	s.ByLanguage = mergeGroups(s.ByLanguage, other.ByLanguage)
+	s.ByTool = mergeGroups(s.ByTool, other.ByTool)
+	s.ByAuthor = mergeGroups(s.ByAuthor, other.ByAuthor)
+	s.ByFile = mergeGroups(s.ByFile, other.ByFile)
+}
+
+func mergeGroups[K comparable](groups, others map[K]Counts) map[K]Counts {
+	if groups == nil {
+		groups = map[K]Counts{}
+	}
+
+	for key, counts := range others {
+		groups[key] = groups[key].merge(counts)
+	}
+
+	return groups
+}
+
+// GetTotalVulnerabilities returns the total of vulnerabilities of the summary.
+func (s *Summary) GetTotalVulnerabilities() int {
+	return s.Total.GetTotal()
+}
+
+// Get returns the total of vulnerabilities with the severity and type.
+func (c Counts) Get(severity severities.Severity, vulnType vulnerabilityEnums.Type) int {
+	return c[severity][vulnType]
+}
+
+// GetTotal returns the total of vulnerabilities of all severities and types.
+func (c Counts) GetTotal() (total int) {
+	for severity := range c {
+		total += c.GetTotalBySeverity(severity)
+	}
+
+	return total
+}
+
+// GetTotalBySeverity returns the total of vulnerabilities with the severity of all types.
+func (c Counts) GetTotalBySeverity(severity severities.Severity) (total int) {
+	for _, count := range c[severity] {
+		total += count
+	}
+
+	return total
+}
+
+// GetTotalByType returns the total of vulnerabilities with the type of all severities.
+func (c Counts) GetTotalByType(vulnType vulnerabilityEnums.Type) (total int) {
+	for _, types := range c {
+		total += types[vulnType]
+	}
+
+	return total
+}
+
+func (c Counts) add(severity severities.Severity, vulnType vulnerabilityEnums.Type, total int) {
+	if c[severity] == nil {
+		c[severity] = map[vulnerabilityEnums.Type]int{}
+	}
+
+	c[severity][vulnType] += total
+}
+
+// merge returns the counts with the totals of the other counts added, allocating them when nil, so the maps of the
+// other counts are never shared.
+func (c Counts) merge(other Counts) Counts {
+	if c == nil {
+		c = Counts{}
+	}
+
+	for severity, types := range other {
+		for vulnType, total := range types {
+			c.add(severity, vulnType, total)
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,136 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/languages"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+)
+
+func newSummaryTestAnalysis() *Analysis {
+	return &Analysis{AnalysisVulnerabilities: []AnalysisVulnerabilities{
+		{Vulnerability: vulnerability.Vulnerability{Severity: severities.High, Type: vulnerabilityEnums.Vulnerability,
+			Language: languages.Go, SecurityTool: tools.GoSec, CommitEmail: "horusec@zup.com.br", File: "main.go"}},
+		{Vulnerability: vulnerability.Vulnerability{Severity: severities.High, Type: vulnerabilityEnums.FalsePositive,
+			Language: languages.Go, SecurityTool: tools.HorusecEngine, CommitAuthor: "horusec", File: "main.go"}},
+		{Vulnerability: vulnerability.Vulnerability{Severity: severities.Low, Type: vulnerabilityEnums.Vulnerability,
+			Language: languages.Leaks, SecurityTool: tools.GitLeaks}},
+	}}
+}
+
+func TestNewSummary(t *testing.T) {
+	t.Run("should count the vulnerabilities of the analysis", func(t *testing.T) {
+		summary := NewSummary(newSummaryTestAnalysis())
+
+		assert.Equal(t, 1, summary.TotalAnalysis)
+		assert.Equal(t, 3, summary.GetTotalVulnerabilities())
+		assert.Equal(t, 1, summary.Total.Get(severities.High, vulnerabilityEnums.Vulnerability))
+		assert.Equal(t, 1, summary.Total.Get(severities.High, vulnerabilityEnums.FalsePositive))
+		assert.Equal(t, 1, summary.Total.Get(severities.Low, vulnerabilityEnums.Vulnerability))
+		assert.Equal(t, 2, summary.ByLanguage[languages.Go].GetTotal())
+		assert.Equal(t, 1, summary.ByLanguage[languages.Leaks].GetTotal())
+		assert.Len(t, summary.ByTool, 3)
+		assert.Equal(t, 1, summary.ByTool[tools.GitLeaks].GetTotalBySeverity(severities.Low))
+	})
+
+	t.Run("should group by author email or name and ignore empty authors and files", func(t *testing.T) {
+		summary := NewSummary(newSummaryTestAnalysis())
+
+		assert.Len(t, summary.ByAuthor, 2)
+		assert.Equal(t, 1, summary.ByAuthor["horusec@zup.com.br"].GetTotal())
+		assert.Equal(t, 1, summary.ByAuthor["horusec"].GetTotal())
+		assert.Len(t, summary.ByFile, 1)
+		assert.Equal(t, 2, summary.ByFile["main.go"].GetTotal())
+	})
+
+	t.Run("should return an empty summary when nil analysis", func(t *testing.T) {
+		summary := NewSummary(nil)
+
+		assert.Equal(t, 0, summary.TotalAnalysis)
+		assert.Equal(t, 0, summary.GetTotalVulnerabilities())
+		assert.NotNil(t, summary.ByFile)
+	})
+}
+
+func TestSummaryMerge(t *testing.T) {
+	t.Run("should add the totals of the other summary", func(t *testing.T) {
+		summary := NewSummary(newSummaryTestAnalysis())
+		summary.Merge(NewSummary(newSummaryTestAnalysis()))
+
+		assert.Equal(t, 2, summary.TotalAnalysis)
+		assert.Equal(t, 6, summary.GetTotalVulnerabilities())
+		assert.Equal(t, 4, summary.ByLanguage[languages.Go].GetTotal())
+		assert.Equal(t, 4, summary.ByFile["main.go"].GetTotal())
+		assert.Equal(t, 2, summary.ByAuthor["horusec"].GetTotal())
+	})
+
+	t.Run("should merge a summary without allocated maps", func(t *testing.T) {
+		summary := &Summary{}
+		summary.Merge(NewSummary(newSummaryTestAnalysis()))
+		summary.Merge(nil)
+
+		assert.Equal(t, 3, summary.GetTotalVulnerabilities())
+		assert.Len(t, summary.ByTool, 3)
+	})
+
+	t.Run("should merge a summary decoded from json", func(t *testing.T) {
+		data, _ := json.Marshal(NewSummary(newSummaryTestAnalysis()))
+
+		decoded := &Summary{}
+		assert.NoError(t, json.Unmarshal(data, decoded))
+
+		summary := NewSummary(newSummaryTestAnalysis())
+		summary.Merge(decoded)
+		assert.Equal(t, 6, summary.GetTotalVulnerabilities())
+		assert.Equal(t, 2, summary.Total.Get(severities.Low, vulnerabilityEnums.Vulnerability))
+	})
+}
+
+func TestMergeSummaries(t *testing.T) {
+	t.Run("should return the sum without changing the summaries", func(t *testing.T) {
+		first := NewSummary(newSummaryTestAnalysis())
+		second := NewSummary(newSummaryTestAnalysis())
+
+		merged := MergeSummaries(first, second, nil)
+
+		assert.Equal(t, 2, merged.TotalAnalysis)
+		assert.Equal(t, 6, merged.GetTotalVulnerabilities())
+		assert.Equal(t, 3, first.GetTotalVulnerabilities())
+		assert.Equal(t, 3, second.GetTotalVulnerabilities())
+
+		merged.Total.add(severities.Critical, vulnerabilityEnums.Vulnerability, 1)
+		assert.Equal(t, 3, first.GetTotalVulnerabilities())
+	})
+}
+
+func TestCounts(t *testing.T) {
+	t.Run("should return the totals by severity and type", func(t *testing.T) {
+		counts := NewSummary(newSummaryTestAnalysis()).Total
+
+		assert.Equal(t, 2, counts.GetTotalBySeverity(severities.High))
+		assert.Equal(t, 0, counts.GetTotalBySeverity(severities.Critical))
+		assert.Equal(t, 2, counts.GetTotalByType(vulnerabilityEnums.Vulnerability))
+		assert.Equal(t, 1, counts.GetTotalByType(vulnerabilityEnums.FalsePositive))
+		assert.Equal(t, 0, counts.Get(severities.Info, vulnerabilityEnums.Corrected))
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/health"
+)
+
+// IChecker is implemented by the services able to report their health, like the database health checker.
+type IChecker interface {
+	CheckHealth(ctx context.Context) *Report
+}
+
+// Check is the result of a single verification of a service, like the ping of a database connection.
+type Check struct {
+	Name      string                 `json:"name"`
+	Status    health.Status          `json:"status"`
+	LatencyMs float64                `json:"latencyMs,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+}
+
+// Report is the health report served by the http readiness and liveness routes and by the grpc health server,
+// where the status is the worst status of its checks.
+type Report struct {
+	Status    health.Status `json:"status"`
+	Checks    []*Check      `json:"checks"`
+	CheckedAt time.Time     `json:"checkedAt"`
+}
+
+func NewCheck(name string, status health.Status) *Check {
+	return &Check{
+		Name:    name,
+		Status:  status,
+		Details: map[string]interface{}{},
+	}
+}
+
+// SetLatency sets the latency of the check in milliseconds.
+func (c *Check) SetLatency(latency time.Duration) *Check {
+	c.LatencyMs = float64(latency) / float64(time.Millisecond)
+
+	return c
+}
+
+func (c *Check) SetDetail(key string, value interface{}) *Check {
+	c.Details[key] = value
+
+	return c
+}
+
+// SetError sets the error message of the check and changes its status, keeping the worst status.
+func (c *Check) SetError(status health.Status, err error) *Check {
+	if err != nil {
+		c.Error = err.Error()
+	}
+
+	if status.IsWorseThan(c.Status) {
+		c.Status = status
+	}
+
+	return c
+}
+
+func NewReport(checks ...*Check) *Report {
+	report := &Report{Status: health.Up, Checks: []*Check{}, CheckedAt: time.Now()}
+
+	return report.AddChecks(checks...)
+}
+
+// CheckAll returns a single report merging the reports of all checkers.
+func CheckAll(ctx context.Context, checkers ...IChecker) *Report {
+	report := NewReport()
+
+	for _, checker := range checkers {
+		report.Merge(checker.CheckHealth(ctx))
+	}
+
+	return report
+}
+
+// AddChecks adds the checks into the report, updating the report status when a check has a worse one.
+func (r *Report) AddChecks(checks ...*Check) *Report {
+	for _, check := range checks {
+		if check.Status.IsWorseThan(r.Status) {
+			r.Status = check.Status
+		}
+
+		r.Checks = append(r.Checks, check)
+	}
+
+	return r
+}
+
+// Merge adds the checks of the other reports into the report.
+func (r *Report) Merge(reports ...*Report) *Report {
+	for _, report := range reports {
+		r.AddChecks(report.Checks...)
+	}
+
+	return r
+}
+
+// IsAvailable returns true when the report is not down, which means that the service could still receive requests
+// even when degraded.
+func (r *Report) IsAvailable() bool {
+	return r.Status != health.Down
+}
+
+func (r *Report) ToBytes() []byte {
+	bytes, _ := json.Marshal(r)
+
+	return bytes
+}
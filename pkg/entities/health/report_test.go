@@ -0,0 +1,96 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/health"
+)
+
+func TestNewCheck(t *testing.T) {
+	t.Run("should success create a check with latency and details", func(t *testing.T) {
+		check := NewCheck("test", health.Up).SetLatency(1500*time.Microsecond).SetDetail("rows", 1)
+
+		assert.Equal(t, "test", check.Name)
+		assert.Equal(t, 1.5, check.LatencyMs)
+		assert.Equal(t, 1, check.Details["rows"])
+	})
+}
+
+func TestSetError(t *testing.T) {
+	t.Run("should set the error and keep the worst status", func(t *testing.T) {
+		check := NewCheck("test", health.Up).SetError(health.Down, errors.New("test"))
+		check.SetError(health.Degraded, nil)
+
+		assert.Equal(t, health.Down, check.Status)
+		assert.Equal(t, "test", check.Error)
+	})
+}
+
+func TestNewReport(t *testing.T) {
+	t.Run("should return up when no checks", func(t *testing.T) {
+		report := NewReport()
+
+		assert.Equal(t, health.Up, report.Status)
+		assert.Empty(t, report.Checks)
+		assert.True(t, report.IsAvailable())
+	})
+
+	t.Run("should use the worst status of the checks", func(t *testing.T) {
+		report := NewReport(NewCheck("first", health.Up), NewCheck("second", health.Degraded))
+
+		assert.Equal(t, health.Degraded, report.Status)
+		assert.True(t, report.IsAvailable())
+	})
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("should add the checks of all reports", func(t *testing.T) {
+		report := NewReport(NewCheck("first", health.Up)).Merge(NewReport(NewCheck("second", health.Down)))
+
+		assert.Len(t, report.Checks, 2)
+		assert.Equal(t, health.Down, report.Status)
+		assert.False(t, report.IsAvailable())
+	})
+}
+
+type testChecker struct {
+	status health.Status
+}
+
+func (c *testChecker) CheckHealth(_ context.Context) *Report {
+	return NewReport(NewCheck("test", c.status))
+}
+
+func TestCheckAll(t *testing.T) {
+	t.Run("should merge the reports of all checkers", func(t *testing.T) {
+		report := CheckAll(context.Background(), &testChecker{status: health.Up}, &testChecker{status: health.Degraded})
+
+		assert.Len(t, report.Checks, 2)
+		assert.Equal(t, health.Degraded, report.Status)
+	})
+}
+
+func TestToBytes(t *testing.T) {
+	t.Run("should success parse the report to bytes", func(t *testing.T) {
+		assert.Contains(t, string(NewReport(NewCheck("test", health.Up)).ToBytes()), `"status":"up"`)
+	})
+}
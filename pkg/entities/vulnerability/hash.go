@@ -0,0 +1,138 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/crypto"
+)
+
+// hashSeparator separates the fields of the canonical hash, avoiding the same hash for different fields that
+// result in the same value when concatenated.
+const hashSeparator = "\x00"
+
+// GenerateHash returns the vulnerability hash generated by the algorithm of the version, or an empty string when the
+// version does not exist.
+func (v *Vulnerability) GenerateHash(version vulnerability.HashVersion) string {
+	generators := map[vulnerability.HashVersion]func() string{
+		vulnerability.HashVersionV1: v.generateHashV1,
+		vulnerability.HashVersionV2: v.generateHashV2,
+		vulnerability.HashVersionV3: v.generateHashV3,
+	}
+
+	if generate, ok := generators[version]; ok {
+		return generate()
+	}
+
+	return ""
+}
+
+// SetHashes sets the VulnHash with the current hash version and the DeprecatedHashes with the hashes of all previous
+// versions, so vulnerabilities classified with an older hash are still found.
+// Usage example: vuln.SetHashes()
+func (v *Vulnerability) SetHashes() {
+	v.VulnHash = v.GenerateHash(vulnerability.CurrentHashVersion)
+	v.DeprecatedHashes = v.GetLegacyHashes()
+}
+
+// GetLegacyHashes returns the hashes generated by all deprecated versions, ignoring the ones equal to the current.
+func (v *Vulnerability) GetLegacyHashes() (hashes []string) {
+	current := v.GenerateHash(vulnerability.CurrentHashVersion)
+
+	for _, version := range vulnerability.HashVersions() {
+		if hash := v.GenerateHash(version); version.IsDeprecated() && hash != current && !contains(hashes, hash) {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return hashes
+}
+
+// GetHashVersion returns the version of the algorithm that generates the hash for this vulnerability, returning
+// false when the hash does not belong to the vulnerability. Hashes with deprecated versions should be migrated to
+// the current VulnHash.
+func (v *Vulnerability) GetHashVersion(hash string) (vulnerability.HashVersion, bool) {
+	for _, version := range vulnerability.HashVersions() {
+		if hash != "" && v.GenerateHash(version) == hash {
+			return version, true
+		}
+	}
+
+	return 0, false
+}
+
+// MatchHash returns true when the hash was generated for this vulnerability by any hash version.
+func (v *Vulnerability) MatchHash(hash string) bool {
+	_, ok := v.GetHashVersion(hash)
+
+	return ok
+}
+
+// SetRelativeFile sets the file relative to the project path, which is required to generate the same hash when the
+// project is analyzed on different directories. The file is not changed when it is outside of the project path.
+func (v *Vulnerability) SetRelativeFile(projectPath string) {
+	relative, err := filepath.Rel(projectPath, v.File)
+	if err != nil || strings.HasPrefix(relative, "..") {
+		return
+	}
+
+	v.File = relative
+}
+
+func (v *Vulnerability) generateHashV1() string {
+	return crypto.GenerateSHA256(toOneLine(v.Code), v.Line, v.Details, v.File, v.CommitEmail)
+}
+
+func (v *Vulnerability) generateHashV2() string {
+	return crypto.GenerateSHA256(toOneLine(v.Code), v.Line, v.Details, v.File)
+}
+
+func (v *Vulnerability) generateHashV3() string {
+	return crypto.GenerateSHA256(strings.Join([]string{normalizeCode(v.Code), normalizeFile(v.File), v.RuleID,
+		v.SecurityTool.ToString()}, hashSeparator))
+}
+
+func toOneLine(code string) string {
+	return strings.NewReplacer("\r", "", "\n", "", "\t", "").Replace(code)
+}
+
+// normalizeCode removes the indentation and the line breaks, keeping a single space between the words.
+func normalizeCode(code string) string {
+	return strings.Join(strings.Fields(code), " ")
+}
+
+// normalizeFile returns the file with slashes as separator, including the ones generated on windows, and without the
+// current directory prefix.
+func normalizeFile(file string) string {
+	if file == "" {
+		return file
+	}
+
+	return strings.TrimPrefix(path.Clean(strings.ReplaceAll(file, "\\", "/")), "./")
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
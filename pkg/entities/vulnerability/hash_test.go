@@ -0,0 +1,126 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/crypto"
+)
+
+func getHashTestVulnerability() *Vulnerability {
+	return &Vulnerability{
+		Code:         "if password == \"123\" {\n\treturn true\n}",
+		Line:         "10",
+		Details:      "hardcoded password",
+		File:         "./internal/auth.go",
+		CommitEmail:  "horusec@zup.com.br",
+		RuleID:       "HS-GO-1",
+		SecurityTool: tools.HorusecEngine,
+	}
+}
+
+func TestGenerateHash(t *testing.T) {
+	t.Run("should generate the legacy hashes", func(t *testing.T) {
+		vuln := getHashTestVulnerability()
+
+		assert.Equal(t, crypto.GenerateSHA256("if password == \"123\" {return true}", "10", "hardcoded password",
+			"./internal/auth.go", "horusec@zup.com.br"), vuln.GenerateHash(vulnerability.HashVersionV1))
+		assert.Equal(t, crypto.GenerateSHA256("if password == \"123\" {return true}", "10", "hardcoded password",
+			"./internal/auth.go"), vuln.GenerateHash(vulnerability.HashVersionV2))
+	})
+
+	t.Run("should generate the same canonical hash when the vulnerability moves", func(t *testing.T) {
+		vuln := getHashTestVulnerability()
+		moved := getHashTestVulnerability()
+		moved.Line = "20"
+		moved.Details = "another details"
+		moved.Code = "  if password ==   \"123\" {\r\n    return true\r\n  }"
+		moved.File = "internal\\auth.go"
+
+		assert.Len(t, vuln.GenerateHash(vulnerability.HashVersionV3), 64)
+		assert.Equal(t, vuln.GenerateHash(vulnerability.HashVersionV3), moved.GenerateHash(vulnerability.HashVersionV3))
+		assert.NotEqual(t, vuln.GenerateHash(vulnerability.HashVersionV2), moved.GenerateHash(vulnerability.HashVersionV2))
+	})
+
+	t.Run("should generate different canonical hashes for different rules", func(t *testing.T) {
+		vuln := getHashTestVulnerability()
+		other := getHashTestVulnerability()
+		other.RuleID = "HS-GO-2"
+
+		assert.NotEqual(t, vuln.GenerateHash(vulnerability.HashVersionV3), other.GenerateHash(vulnerability.HashVersionV3))
+	})
+
+	t.Run("should return empty when invalid version", func(t *testing.T) {
+		assert.Empty(t, getHashTestVulnerability().GenerateHash(0))
+	})
+}
+
+func TestSetHashes(t *testing.T) {
+	t.Run("should set the current and the legacy hashes", func(t *testing.T) {
+		vuln := getHashTestVulnerability()
+
+		vuln.SetHashes()
+
+		assert.Equal(t, vuln.GenerateHash(vulnerability.CurrentHashVersion), vuln.VulnHash)
+		assert.Equal(t, []string{vuln.GenerateHash(vulnerability.HashVersionV1),
+			vuln.GenerateHash(vulnerability.HashVersionV2)}, vuln.DeprecatedHashes)
+	})
+}
+
+func TestGetHashVersion(t *testing.T) {
+	t.Run("should return the version of the hash", func(t *testing.T) {
+		vuln := getHashTestVulnerability()
+
+		for _, expected := range vulnerability.HashVersions() {
+			version, ok := vuln.GetHashVersion(vuln.GenerateHash(expected))
+
+			assert.True(t, ok)
+			assert.Equal(t, expected, version)
+			assert.True(t, vuln.MatchHash(vuln.GenerateHash(expected)))
+		}
+	})
+
+	t.Run("should return false when the hash is not of the vulnerability", func(t *testing.T) {
+		vuln := getHashTestVulnerability()
+
+		_, ok := vuln.GetHashVersion("invalid")
+
+		assert.False(t, ok)
+		assert.False(t, vuln.MatchHash(""))
+	})
+}
+
+func TestSetRelativeFile(t *testing.T) {
+	t.Run("should set the file relative to the project path", func(t *testing.T) {
+		vuln := &Vulnerability{File: "/tmp/project/internal/auth.go"}
+
+		vuln.SetRelativeFile("/tmp/project")
+
+		assert.Equal(t, "internal/auth.go", vuln.File)
+	})
+
+	t.Run("should not change the file when outside of the project path", func(t *testing.T) {
+		vuln := &Vulnerability{File: "/tmp/other/auth.go"}
+
+		vuln.SetRelativeFile("/tmp/project")
+
+		assert.Equal(t, "/tmp/other/auth.go", vuln.File)
+	})
+}
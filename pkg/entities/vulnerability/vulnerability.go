@@ -15,6 +15,7 @@
 package vulnerability
 
 import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 
 	"github.com/Fotkurz/horusec-devkit/pkg/enums/confidence"
@@ -22,11 +23,14 @@ import (
 	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
 	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
 	"github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cvss"
+	validationUtils "github.com/Fotkurz/horusec-devkit/pkg/utils/validation"
 )
 
 // Vulnerability this struct represents a possible vulnerability and contains all necessary data to identify it.
-// TODO: The fields CWEs, CVEs, Mitigation, Reference, SafeExample, UnsafeExample are going to be ignored until we
-// start to fill the data into the engine rules. After completed it's necessary to add then into the json notation.
+// The CWEs are sent into the json notation, so the vulnerabilities could be grouped by weakness, see the cwe package.
+// TODO: The fields CVEs, Mitigation, Reference, SafeExample, UnsafeExample are going to be ignored until we start to
+// fill the data into the engine rules. After completed it's necessary to add then into the json notation.
 //
 //nolint:lll // notations need more than 130 characters
 type Vulnerability struct {
@@ -42,7 +46,7 @@ type Vulnerability struct {
 	Severity        severities.Severity   `json:"severity" gorm:"Column:severity" example:"CRITICAL" enums:"CRITICAL, HIGH, MEDIUM, LOW, INFO"`
 	Type            vulnerability.Type    `json:"type" gorm:"Column:type" example:"Vulnerability" enums:"Vulnerability, Risk Accepted, False Positive, Corrected"`
 
-	CWEs          []string `json:"-" gorm:"-" example:"[\"https://cwe.mitre.org/data/definitions/000.html\"]"`
+	CWEs          []string `json:"cwes,omitempty" gorm:"-" example:"[\"https://cwe.mitre.org/data/definitions/000.html\"]"`
 	CVEs          []string `json:"-" gorm:"-" example:"[\"https://cve.mitre.org/cgi-bin/cvename.cgi?name=CVE-0000-00000\"]"`
 	Mitigation    string   `json:"-" gorm:"-" example:"Use a secret manager or environment variable"`
 	Reference     string   `json:"-" gorm:"-" example:"https://example.com"`
@@ -68,6 +72,11 @@ type Vulnerability struct {
 	// TODO: This will be removed after the release v2.10.0 of the Horusec CLI be released.
 	DeprecatedHashes []string `json:"deprecatedHashes" gorm:"-" example:""`
 
+	// CVSSVector is the optional CVSS 3.0 or 3.1 vector of the vulnerability, usually reported by the dependency tools,
+	// which allows to rank the vulnerabilities with the same severity by their scores. The column is added by the
+	// migrations of the devkit, see migrations.Files.
+	CVSSVector string `json:"cvssVector,omitempty" gorm:"Column:cvss_vector" example:"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"`
+
 	SecurityToolVersion string `json:"securityToolVersion" gorm:"-"`
 	SecurityToolInfoURI string `json:"securityToolInfoUri" gorm:"-"`
 }
@@ -76,6 +85,27 @@ func (v *Vulnerability) GetTable() string {
 	return "vulnerabilities"
 }
 
+// Validate returns the errors of all invalid fields, which is checked by the database service before writing it.
+func (v *Vulnerability) Validate() error {
+	return validation.ValidateStruct(v,
+		validation.Field(&v.VulnerabilityID, validationUtils.NotNilUUIDRule()),
+		validation.Field(&v.Severity, validation.Required, validationUtils.InValuesRule(severities.Values())),
+		validation.Field(&v.Confidence, validation.Required, validationUtils.InValuesRule(confidence.Values())),
+		validation.Field(&v.Type, validation.Required, validationUtils.InValuesRule(vulnerability.Values())),
+		validation.Field(&v.CVSSVector, validation.By(v.validateCVSSVector)),
+	)
+}
+
+func (v *Vulnerability) validateCVSSVector(_ interface{}) error {
+	if v.CVSSVector == "" {
+		return nil
+	}
+
+	_, err := cvss.Parse(v.CVSSVector)
+
+	return err
+}
+
 func (v *Vulnerability) GenerateID() {
 	v.VulnerabilityID = uuid.New()
 }
@@ -91,3 +121,21 @@ func (v *Vulnerability) SetType(vulnType vulnerability.Type) {
 func (v *Vulnerability) SetSeverity(severity severities.Severity) {
 	v.Severity = severity
 }
+
+// GetCVSSScores returns the base and temporal scores of the CVSS vector, see cvss.Calculate.
+func (v *Vulnerability) GetCVSSScores() (*cvss.Scores, error) {
+	return cvss.Calculate(v.CVSSVector)
+}
+
+// SetSeverityByCVSS sets the severity mapped from the temporal score of the CVSS vector, keeping the current severity
+// when the vector is invalid or its score can not be calculated.
+func (v *Vulnerability) SetSeverityByCVSS() error {
+	scores, err := v.GetCVSSScores()
+	if err != nil {
+		return err
+	}
+
+	v.Severity = cvss.GetSeverity(scores.Temporal)
+
+	return nil
+}
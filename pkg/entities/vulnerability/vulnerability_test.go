@@ -17,9 +17,11 @@ package vulnerability
 import (
 	"testing"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/confidence"
 	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
 	vulnerabilityEnum "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
 )
@@ -31,6 +33,28 @@ func TestGetTable(t *testing.T) {
 	})
 }
 
+func TestValidate(t *testing.T) {
+	t.Run("should return no error when valid vulnerability", func(t *testing.T) {
+		vulnerability := &Vulnerability{VulnerabilityID: uuid.New(), Severity: severities.High,
+			Confidence: confidence.Medium, Type: vulnerabilityEnum.Vulnerability}
+
+		assert.NoError(t, vulnerability.Validate())
+	})
+
+	t.Run("should return the errors of all invalid fields", func(t *testing.T) {
+		vulnerability := &Vulnerability{Severity: "test", Type: "test", CVSSVector: "CVSS:3.1/AV:N"}
+
+		var validationErrors validation.Errors
+		assert.ErrorAs(t, vulnerability.Validate(), &validationErrors)
+		assert.Len(t, validationErrors, 5)
+		assert.Contains(t, validationErrors, "vulnerabilityID")
+		assert.Contains(t, validationErrors, "severity")
+		assert.Contains(t, validationErrors, "confidence")
+		assert.Contains(t, validationErrors, "type")
+		assert.Contains(t, validationErrors, "cvssVector")
+	})
+}
+
 func TestGenerateID(t *testing.T) {
 	t.Run("should success generate vulnerability id", func(t *testing.T) {
 		vulnerability := &Vulnerability{}
@@ -64,3 +88,20 @@ func TestSetSeverity(t *testing.T) {
 		assert.Equal(t, severities.High, vulnerability.Severity)
 	})
 }
+
+func TestSetSeverityByCVSS(t *testing.T) {
+	t.Run("should set the severity by the temporal score", func(t *testing.T) {
+		vulnerability := &Vulnerability{Severity: severities.Unknown,
+			CVSSVector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:P/RL:O/RC:C"}
+
+		assert.NoError(t, vulnerability.SetSeverityByCVSS())
+		assert.Equal(t, severities.High, vulnerability.Severity)
+	})
+
+	t.Run("should keep the severity when invalid vector", func(t *testing.T) {
+		vulnerability := &Vulnerability{Severity: severities.Unknown, CVSSVector: "invalid"}
+
+		assert.Error(t, vulnerability.SetSeverityByCVSS())
+		assert.Equal(t, severities.Unknown, vulnerability.Severity)
+	})
+}
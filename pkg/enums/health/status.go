@@ -0,0 +1,53 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+type Status string
+
+const (
+	Up       Status = "up"
+	Unknown  Status = "unknown"
+	Degraded Status = "degraded"
+	Down     Status = "down"
+)
+
+func Values() []Status {
+	return []Status{
+		Up,
+		Unknown,
+		Degraded,
+		Down,
+	}
+}
+
+func (s Status) ToString() string {
+	return string(s)
+}
+
+// IsWorseThan returns true when the status is more severe than the other one, being down worse than degraded and
+// degraded worse than up.
+func (s Status) IsWorseThan(other Status) bool {
+	return s.getWeight() > other.getWeight()
+}
+
+func (s Status) getWeight() int {
+	for weight, status := range Values() {
+		if status == s {
+			return weight
+		}
+	}
+
+	return len(Values())
+}
@@ -0,0 +1,47 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValues(t *testing.T) {
+	t.Run("should return 4 valid values", func(t *testing.T) {
+		assert.Len(t, Values(), 4)
+	})
+}
+
+func TestToString(t *testing.T) {
+	t.Run("should return 4 valid values", func(t *testing.T) {
+		assert.Equal(t, "up", Up.ToString())
+		assert.Equal(t, "unknown", Unknown.ToString())
+		assert.Equal(t, "degraded", Degraded.ToString())
+		assert.Equal(t, "down", Down.ToString())
+	})
+}
+
+func TestIsWorseThan(t *testing.T) {
+	t.Run("should compare the status severity", func(t *testing.T) {
+		assert.True(t, Down.IsWorseThan(Degraded))
+		assert.True(t, Degraded.IsWorseThan(Unknown))
+		assert.True(t, Unknown.IsWorseThan(Up))
+		assert.False(t, Up.IsWorseThan(Degraded))
+		assert.False(t, Down.IsWorseThan(Down))
+		assert.True(t, Status("invalid").IsWorseThan(Down))
+	})
+}
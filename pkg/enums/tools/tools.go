@@ -78,3 +78,27 @@ func Values() []Tool {
 		Trivy,
 	}
 }
+
+// DependencyTools returns the tools that audit the project dependencies, whose vulnerabilities affect a third party
+// component instead of the project source code.
+func DependencyTools() []Tool {
+	return []Tool{
+		Safety,
+		NpmAudit,
+		YarnAudit,
+		BundlerAudit,
+		OwaspDependencyCheck,
+		Nancy,
+		Trivy,
+	}
+}
+
+func (t Tool) IsDependencyTool() bool {
+	for _, tool := range DependencyTools() {
+		if t == tool {
+			return true
+		}
+	}
+
+	return false
+}
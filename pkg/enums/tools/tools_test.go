@@ -79,3 +79,16 @@ func TestValues(t *testing.T) {
 		assert.Len(t, Values(), 22)
 	})
 }
+
+func TestIsDependencyTool(t *testing.T) {
+	t.Run("should return true when the tool audits dependencies", func(t *testing.T) {
+		for _, tool := range DependencyTools() {
+			assert.True(t, tool.IsDependencyTool())
+		}
+	})
+
+	t.Run("should return false when the tool analyzes source code", func(t *testing.T) {
+		assert.False(t, HorusecEngine.IsDependencyTool())
+		assert.False(t, GoSec.IsDependencyTool())
+	})
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+// HashVersion is the version of the algorithm used to generate the vulnerability hash. Each version uses different
+// fields of the vulnerability, so the hashes generated by old versions are kept as deprecated hashes, avoiding
+// breaking the vulnerabilities already classified by the users.
+type HashVersion int
+
+const (
+	// HashVersionV1 uses the code in one line, line, details, file and commit email.
+	HashVersionV1 HashVersion = iota + 1
+	// HashVersionV2 uses the code in one line, line, details and file.
+	HashVersionV2
+	// HashVersionV3 uses the normalized code, relative file, rule id and security tool, which does not change when
+	// the vulnerability is moved to another line or the tool changes the vulnerability details.
+	HashVersionV3
+)
+
+const CurrentHashVersion = HashVersionV3
+
+func (h HashVersion) IsDeprecated() bool {
+	return h < CurrentHashVersion
+}
+
+func HashVersions() []HashVersion {
+	return []HashVersion{
+		HashVersionV1,
+		HashVersionV2,
+		HashVersionV3,
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDeprecated(t *testing.T) {
+	t.Run("should return true for the versions before the current", func(t *testing.T) {
+		assert.True(t, HashVersionV1.IsDeprecated())
+		assert.True(t, HashVersionV2.IsDeprecated())
+		assert.False(t, CurrentHashVersion.IsDeprecated())
+	})
+}
+
+func TestHashVersions(t *testing.T) {
+	t.Run("should return 3 versions ending with the current", func(t *testing.T) {
+		assert.Len(t, HashVersions(), 3)
+		assert.Equal(t, CurrentHashVersion, HashVersions()[len(HashVersions())-1])
+	})
+}
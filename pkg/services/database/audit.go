@@ -0,0 +1,72 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"time"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/audit"
+	auditEnums "github.com/Fotkurz/horusec-devkit/pkg/services/database/audit/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/query"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
+)
+
+// setAudit registers the callbacks filling the audit columns and, when the audit log is enabled, checks that the
+// audit log table was created by the devkit migrations, see migrations.Files.
+func (d *database) setAudit() error {
+	if err := audit.RegisterCallbacks(d.connectionWrite, d.config.GetAuditLog()); err != nil {
+		return err
+	}
+
+	if !d.config.GetAuditLog() {
+		return nil
+	}
+
+	if !d.connectionWrite.Migrator().HasTable(auditEnums.AuditLogTable) {
+		return enums.ErrorAuditLogTableNotFound
+	}
+
+	return nil
+}
+
+// SoftDelete fills the deleted_at column of the records matching the where clause that are not deleted yet, which
+// should be used with entities embedding audit.SoftDelete. The audit log records it as a delete.
+func (d *database) SoftDelete(where map[string]interface{}, table string) response.IResponse {
+	result := d.connectionWrite.Table(table).Set(auditEnums.SettingSoftDelete, true).Where(where).
+		Where(auditEnums.ColumnDeletedAt+" IS NULL").
+		UpdateColumn(auditEnums.ColumnDeletedAt, time.Now())
+
+	return response.NewResponse(result.RowsAffected, result.Error, nil)
+}
+
+// FindAuditLogs returns the audit logs of the table matching the query, ordered from the newest to the oldest when
+// the query has no order. An empty table returns the audit logs of all tables. The response data is *[]audit.Log.
+func (d *database) FindAuditLogs(table string, spec *query.Query) response.IResponse {
+	if spec == nil {
+		spec = query.New()
+	}
+
+	spec = spec.Clone()
+	if table != "" {
+		spec.Where(auditEnums.ColumnTableName, query.Equal, table)
+	}
+
+	if len(spec.GetOrders()) == 0 {
+		spec.OrderBy(auditEnums.ColumnCreatedAt, query.Descending)
+	}
+
+	return d.FindByQuery(&[]audit.Log{}, spec, auditEnums.AuditLogTable)
+}
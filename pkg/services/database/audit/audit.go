@@ -0,0 +1,76 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/audit/enums"
+)
+
+type accountIDKey struct{}
+
+// Columns when embedded into an entity, the created_by and updated_by columns are filled with the account id
+// carried by the context of the write, see WithAccountID. The updated_at column is filled by gorm itself.
+type Columns struct {
+	CreatedBy uuid.UUID `json:"createdBy" gorm:"Column:created_by"`
+	UpdatedBy uuid.UUID `json:"updatedBy" gorm:"Column:updated_by"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"Column:updated_at"`
+}
+
+// SoftDelete when embedded into an entity, the records with deleted_at filled are ignored by the reads made using
+// the entity, and the deletes made with the entity only fill the deleted_at column.
+type SoftDelete struct {
+	DeletedAt gorm.DeletedAt `json:"deletedAt" gorm:"Column:deleted_at;index"`
+}
+
+// Log is a change made into a table, written by the database callbacks in the same transaction of the change.
+// Filter contains the where clause of updates and deletes, and Changes the json of the columns written.
+type Log struct {
+	ID        uuid.UUID `json:"id" gorm:"Column:audit_log_id;primaryKey"`
+	Table     string    `json:"table" gorm:"Column:table_name;index"`
+	Operation string    `json:"operation" gorm:"Column:operation"`
+	Filter    string    `json:"filter" gorm:"Column:filter"`
+	Changes   string    `json:"changes" gorm:"Column:changes"`
+	AccountID uuid.UUID `json:"accountID" gorm:"Column:account_id;index"`
+	CreatedAt time.Time `json:"createdAt" gorm:"Column:created_at"`
+}
+
+func (l *Log) GetTable() string {
+	return enums.AuditLogTable
+}
+
+// WithAccountID returns a copy of the context carrying the id of the account responsible for the writes made
+// with it. Usage example: connection.Write.WithContext(audit.WithAccountID(ctx, accountID)).Update(...)
+func WithAccountID(ctx context.Context, accountID uuid.UUID) context.Context {
+	return context.WithValue(ctx, accountIDKey{}, accountID)
+}
+
+// GetAccountID returns the account id carried by the context, or uuid.Nil when there is none.
+func GetAccountID(ctx context.Context) uuid.UUID {
+	if ctx == nil {
+		return uuid.Nil
+	}
+
+	if accountID, ok := ctx.Value(accountIDKey{}).(uuid.UUID); ok {
+		return accountID
+	}
+
+	return uuid.Nil
+}
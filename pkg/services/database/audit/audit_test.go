@@ -0,0 +1,48 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/audit/enums"
+)
+
+func TestWithAccountID(t *testing.T) {
+	t.Run("should success set and get the account id of the context", func(t *testing.T) {
+		accountID := uuid.New()
+
+		assert.Equal(t, accountID, GetAccountID(WithAccountID(context.Background(), accountID)))
+	})
+
+	t.Run("should return nil uuid when context has no account id", func(t *testing.T) {
+		assert.Equal(t, uuid.Nil, GetAccountID(context.Background()))
+	})
+
+	t.Run("should return nil uuid when context is nil", func(t *testing.T) {
+		//nolint:staticcheck // testing nil context
+		assert.Equal(t, uuid.Nil, GetAccountID(nil))
+	})
+}
+
+func TestGetTable(t *testing.T) {
+	t.Run("should return the audit log table", func(t *testing.T) {
+		assert.Equal(t, enums.AuditLogTable, (&Log{}).GetTable())
+	})
+}
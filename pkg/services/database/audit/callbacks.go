@@ -0,0 +1,189 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/audit/enums"
+)
+
+// RegisterCallbacks registers into the connection the callbacks filling the audit columns and, when the log is
+// enabled, the ones writing an audit log for every create, update and delete made with the connection. The audit log
+// is written before the commit of the change, so a failure writing it also rollbacks the change.
+func RegisterCallbacks(connection *gorm.DB, enableLog bool) error {
+	err := connection.Callback().Create().Before(enums.GormCreate).Register(enums.CallbackCreateColumns,
+		setCreateColumns)
+	if err != nil {
+		return err
+	}
+
+	err = connection.Callback().Update().Before(enums.GormUpdate).Register(enums.CallbackUpdateColumns,
+		setUpdateColumns)
+	if err != nil || !enableLog {
+		return err
+	}
+
+	return registerLogCallbacks(connection)
+}
+
+func registerLogCallbacks(connection *gorm.DB) error {
+	err := connection.Callback().Create().After(enums.GormCreate).Before(enums.GormCommitOrRollback).
+		Register(enums.CallbackCreateLog, writeCreateLog)
+	if err != nil {
+		return err
+	}
+
+	err = connection.Callback().Update().After(enums.GormUpdate).Before(enums.GormCommitOrRollback).
+		Register(enums.CallbackUpdateLog, writeUpdateLog)
+	if err != nil {
+		return err
+	}
+
+	return connection.Callback().Delete().After(enums.GormDelete).Before(enums.GormCommitOrRollback).
+		Register(enums.CallbackDeleteLog, writeDeleteLog)
+}
+
+func setCreateColumns(db *gorm.DB) {
+	setColumn(db, enums.ColumnCreatedBy)
+	setColumn(db, enums.ColumnUpdatedBy)
+}
+
+func setUpdateColumns(db *gorm.DB) {
+	setColumn(db, enums.ColumnUpdatedBy)
+}
+
+func setColumn(db *gorm.DB, column string) {
+	accountID := GetAccountID(db.Statement.Context)
+	if db.Error != nil || accountID == uuid.Nil || db.Statement.Schema == nil {
+		return
+	}
+
+	if db.Statement.Schema.LookUpField(column) != nil {
+		db.Statement.SetColumn(column, accountID, true)
+	}
+}
+
+func writeCreateLog(db *gorm.DB) {
+	if !shouldWriteLog(db) {
+		return
+	}
+
+	for _, record := range getCreatedRecords(db) {
+		writeLog(db, enums.OperationCreate, record)
+	}
+}
+
+// writeUpdateLog logs the updates marked with the enums.SettingSoftDelete as deletes, keeping the deleted_at.
+func writeUpdateLog(db *gorm.DB) {
+	if !shouldWriteLog(db) {
+		return
+	}
+
+	if _, ok := db.Get(enums.SettingSoftDelete); ok {
+		writeLog(db, enums.OperationDelete, getAssignments(db))
+
+		return
+	}
+
+	writeLog(db, enums.OperationUpdate, getAssignments(db))
+}
+
+func writeDeleteLog(db *gorm.DB) {
+	if shouldWriteLog(db) {
+		writeLog(db, enums.OperationDelete, nil)
+	}
+}
+
+func shouldWriteLog(db *gorm.DB) bool {
+	return db.Error == nil && db.RowsAffected > 0 && db.Statement.Table != enums.AuditLogTable
+}
+
+func getCreatedRecords(db *gorm.DB) []interface{} {
+	value := reflect.Indirect(db.Statement.ReflectValue)
+	if db.Statement.Schema == nil {
+		return []interface{}{db.Statement.Dest}
+	}
+
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return []interface{}{getColumns(db, value)}
+	}
+
+	records := make([]interface{}, 0, value.Len())
+	for index := 0; index < value.Len(); index++ {
+		records = append(records, getColumns(db, reflect.Indirect(value.Index(index))))
+	}
+
+	return records
+}
+
+func getColumns(db *gorm.DB, value reflect.Value) map[string]interface{} {
+	columns := map[string]interface{}{}
+
+	for _, field := range db.Statement.Schema.Fields {
+		if field.DBName != "" {
+			columns[field.DBName], _ = field.ValueOf(db.Statement.Context, value)
+		}
+	}
+
+	return columns
+}
+
+func getAssignments(db *gorm.DB) map[string]interface{} {
+	columns := map[string]interface{}{}
+
+	if set, ok := db.Statement.Clauses["SET"].Expression.(clause.Set); ok {
+		for _, assignment := range set {
+			columns[assignment.Column.Name] = assignment.Value
+		}
+	}
+
+	return columns
+}
+
+// getFilter returns the where clause of the statement with the values in place of the bind vars.
+func getFilter(db *gorm.DB) string {
+	where, ok := db.Statement.Clauses["WHERE"]
+	if !ok || where.Expression == nil {
+		return ""
+	}
+
+	statement := &gorm.Statement{DB: db, Table: db.Statement.Table, Clauses: map[string]clause.Clause{}}
+	where.Expression.Build(statement)
+
+	return db.Dialector.Explain(statement.SQL.String(), statement.Vars...)
+}
+
+func writeLog(db *gorm.DB, operation string, changes interface{}) {
+	content, err := json.Marshal(changes)
+	if err != nil {
+		_ = db.AddError(err)
+
+		return
+	}
+
+	log := &Log{ID: uuid.New(), Table: db.Statement.Table, Operation: operation, Filter: getFilter(db),
+		Changes: string(content), AccountID: GetAccountID(db.Statement.Context), CreatedAt: time.Now()}
+
+	if err = db.Session(&gorm.Session{NewDB: true}).Table(enums.AuditLogTable).Create(log).Error; err != nil {
+		_ = db.AddError(err)
+	}
+}
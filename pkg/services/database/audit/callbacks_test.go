@@ -0,0 +1,141 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/audit/enums"
+)
+
+type testEntity struct {
+	ID   int    `gorm:"Column:id;primaryKey"`
+	Name string `gorm:"Column:name"`
+	Columns
+	SoftDelete
+}
+
+func getTestConnection(t *testing.T, enableLog bool) *gorm.DB {
+	connection, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory", uuid.NewString())),
+		&gorm.Config{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, connection.Table("test").AutoMigrate(&testEntity{}))
+	assert.NoError(t, connection.Table(enums.AuditLogTable).AutoMigrate(&Log{}))
+	assert.NoError(t, RegisterCallbacks(connection, enableLog))
+
+	return connection
+}
+
+func getTestLogs(t *testing.T, connection *gorm.DB) (logs []Log) {
+	assert.NoError(t, connection.Table(enums.AuditLogTable).Order("created_at").Find(&logs).Error)
+
+	return logs
+}
+
+func TestSetColumns(t *testing.T) {
+	t.Run("should fill the audit columns with the account id of the context", func(t *testing.T) {
+		accountID := uuid.New()
+		connection := getTestConnection(t, false).WithContext(WithAccountID(context.Background(), accountID))
+
+		entity := &testEntity{ID: 1, Name: "test"}
+		assert.NoError(t, connection.Table("test").Create(entity).Error)
+
+		otherAccountID := uuid.New()
+		assert.NoError(t, connection.WithContext(WithAccountID(context.Background(), otherAccountID)).
+			Table("test").Where(map[string]interface{}{"id": 1}).Updates(&testEntity{Name: "updated"}).Error)
+
+		result := &testEntity{}
+		assert.NoError(t, connection.Table("test").First(result).Error)
+		assert.Equal(t, accountID, result.CreatedBy)
+		assert.Equal(t, otherAccountID, result.UpdatedBy)
+		assert.False(t, result.UpdatedAt.IsZero())
+	})
+
+	t.Run("should not fill the audit columns when context has no account id", func(t *testing.T) {
+		connection := getTestConnection(t, false)
+
+		entity := &testEntity{ID: 1, Name: "test"}
+		assert.NoError(t, connection.Table("test").Create(entity).Error)
+
+		assert.Equal(t, uuid.Nil, entity.CreatedBy)
+		assert.Empty(t, getTestLogs(t, connection))
+	})
+}
+
+func TestWriteLogs(t *testing.T) {
+	t.Run("should write the audit log of creates, updates and deletes", func(t *testing.T) {
+		accountID := uuid.New()
+		connection := getTestConnection(t, true).WithContext(WithAccountID(context.Background(), accountID))
+
+		assert.NoError(t, connection.Table("test").Create(&[]testEntity{{ID: 1}, {ID: 2}}).Error)
+		assert.NoError(t, connection.Table("test").Where("id = ?", 1).
+			Updates(map[string]interface{}{"name": "Risk Accepted"}).Error)
+		assert.NoError(t, connection.Table("test").Where("id = ?", 2).Delete(&testEntity{}).Error)
+
+		logs := getTestLogs(t, connection)
+		assert.Len(t, logs, 4)
+		assert.Equal(t, enums.OperationCreate, logs[0].Operation)
+		assert.Contains(t, logs[1].Changes, `"id":2`)
+		assert.Equal(t, enums.OperationUpdate, logs[2].Operation)
+		assert.Equal(t, "id = 1", logs[2].Filter)
+		assert.Equal(t, `{"name":"Risk Accepted"}`, logs[2].Changes)
+		assert.Equal(t, enums.OperationDelete, logs[3].Operation)
+		assert.Contains(t, logs[3].Filter, "id = 2")
+		assert.Equal(t, accountID, logs[3].AccountID)
+		assert.Equal(t, "test", logs[3].Table)
+	})
+
+	t.Run("should not write the audit log when nothing was changed", func(t *testing.T) {
+		connection := getTestConnection(t, true)
+
+		assert.NoError(t, connection.Table("test").Where("id = ?", 1).
+			Updates(map[string]interface{}{"name": "test"}).Error)
+
+		assert.Empty(t, getTestLogs(t, connection))
+	})
+
+	t.Run("should ignore soft deleted records after delete", func(t *testing.T) {
+		connection := getTestConnection(t, true)
+
+		assert.NoError(t, connection.Table("test").Create(&testEntity{ID: 1}).Error)
+		assert.NoError(t, connection.Table("test").Where("id = ?", 1).Delete(&testEntity{}).Error)
+
+		var entities []testEntity
+		assert.NoError(t, connection.Table("test").Find(&entities).Error)
+		assert.Empty(t, entities)
+
+		assert.NoError(t, connection.Table("test").Unscoped().Find(&entities).Error)
+		assert.Len(t, entities, 1)
+	})
+
+	t.Run("should rollback the change when failed to write the audit log", func(t *testing.T) {
+		connection := getTestConnection(t, true)
+		assert.NoError(t, connection.Migrator().DropTable(enums.AuditLogTable))
+
+		assert.Error(t, connection.Table("test").Create(&testEntity{ID: 1}).Error)
+
+		var count int64
+		assert.NoError(t, connection.Table("test").Count(&count).Error)
+		assert.Zero(t, count)
+	})
+}
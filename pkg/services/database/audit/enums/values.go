@@ -0,0 +1,44 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+const (
+	AuditLogTable = "horusec_audit_logs"
+
+	ColumnCreatedBy = "created_by"
+	ColumnUpdatedBy = "updated_by"
+	ColumnDeletedAt = "deleted_at"
+	ColumnCreatedAt = "created_at"
+	ColumnTableName = "table_name"
+
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+
+	CallbackCreateColumns = "horusec:audit_create_columns"
+	CallbackUpdateColumns = "horusec:audit_update_columns"
+	CallbackCreateLog     = "horusec:audit_create_log"
+	CallbackUpdateLog     = "horusec:audit_update_log"
+	CallbackDeleteLog     = "horusec:audit_delete_log"
+
+	GormCreate = "gorm:create"
+	GormUpdate = "gorm:update"
+	GormDelete = "gorm:delete"
+
+	GormCommitOrRollback = "gorm:commit_or_rollback_transaction"
+
+	// SettingSoftDelete marks the updates made by the database service SoftDelete, logged as deletes.
+	SettingSoftDelete = "horusec:audit_soft_delete"
+)
@@ -0,0 +1,162 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/audit"
+	auditEnums "github.com/Fotkurz/horusec-devkit/pkg/services/database/audit/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/config"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/migrations"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/query"
+)
+
+type testAuditEntity struct {
+	ID   int    `gorm:"Column:id;primaryKey"`
+	Type string `gorm:"Column:type"`
+	audit.Columns
+	audit.SoftDelete
+}
+
+func getTestAuditConnection(t *testing.T) *Connection {
+	databaseConfig := config.NewDatabaseConfig()
+	databaseConfig.SetURI("file:" + uuid.NewString() + "?mode=memory&cache=shared")
+	databaseConfig.SetReadURIs(nil)
+	databaseConfig.SetAuditLog(true)
+
+	// the devkit migrations also add columns into the vulnerabilities table of the platform
+	migrationsConnection, err := gorm.Open(sqlite.Open(databaseConfig.GetURI()), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, migrationsConnection.Exec("CREATE TABLE vulnerabilities (vulnerability_id TEXT)").Error)
+
+	_, err = migrations.NewMigrator(migrationsConnection, migrations.Files).Up()
+	assert.NoError(t, err)
+
+	connection, err := NewDatabaseReadAndWrite(databaseConfig)
+	assert.NoError(t, err)
+
+	assert.NoError(t, connection.Write.Exec("CREATE TABLE test_audit (id INTEGER PRIMARY KEY, type TEXT, "+
+		"created_by TEXT, updated_by TEXT, updated_at DATETIME, deleted_at DATETIME)"))
+
+	return connection
+}
+
+func TestSetAudit(t *testing.T) {
+	t.Run("should return error when audit log table was not created by the migrations", func(t *testing.T) {
+		databaseConfig := config.NewDatabaseConfig()
+		databaseConfig.SetURI("file:" + uuid.NewString() + "?mode=memory&cache=shared")
+		databaseConfig.SetReadURIs(nil)
+		databaseConfig.SetAuditLog(true)
+
+		connection, err := NewDatabaseReadAndWrite(databaseConfig)
+		assert.ErrorIs(t, err, enums.ErrorAuditLogTableNotFound)
+		assert.Nil(t, connection)
+	})
+}
+
+func TestWithContext(t *testing.T) {
+	t.Run("should record the account of the context into the audit columns and log", func(t *testing.T) {
+		connection := getTestAuditConnection(t)
+		accountID := uuid.New()
+		write := connection.Write.WithContext(audit.WithAccountID(context.Background(), accountID))
+
+		assert.NoError(t, write.Create(&testAuditEntity{ID: 1, Type: "Vulnerability"}, "test_audit").GetError())
+		assert.NoError(t, write.Update(&testAuditEntity{Type: "Risk Accepted"}, map[string]interface{}{"id": 1},
+			"test_audit").GetError())
+
+		entity := &testAuditEntity{}
+		assert.NoError(t, connection.Read.First(entity, map[string]interface{}{"id": 1}, "test_audit").GetError())
+		assert.Equal(t, accountID, entity.CreatedBy)
+		assert.Equal(t, accountID, entity.UpdatedBy)
+
+		result := connection.Read.FindAuditLogs("test_audit", nil)
+		assert.NoError(t, result.GetError())
+
+		logs := *result.GetData().(*[]audit.Log)
+		assert.Len(t, logs, 2)
+		assert.Equal(t, auditEnums.OperationUpdate, logs[0].Operation)
+		assert.Contains(t, logs[0].Changes, `"type":"Risk Accepted"`)
+		assert.Equal(t, accountID, logs[0].AccountID)
+	})
+}
+
+func TestSoftDelete(t *testing.T) {
+	t.Run("should fill deleted at and ignore the record on reads", func(t *testing.T) {
+		connection := getTestAuditConnection(t)
+
+		assert.NoError(t, connection.Write.Create(&testAuditEntity{ID: 1}, "test_audit").GetError())
+
+		result := connection.Write.SoftDelete(map[string]interface{}{"id": 1}, "test_audit")
+		assert.NoError(t, result.GetError())
+		assert.Equal(t, 1, result.GetRowsAffected())
+
+		result = connection.Write.SoftDelete(map[string]interface{}{"id": 1}, "test_audit")
+		assert.Equal(t, 0, result.GetRowsAffected())
+
+		var entities []testAuditEntity
+		assert.Error(t, connection.Read.Find(&entities, map[string]interface{}{}, "test_audit").GetError())
+	})
+
+	t.Run("should record the soft delete as a delete into the audit log", func(t *testing.T) {
+		connection := getTestAuditConnection(t)
+
+		assert.NoError(t, connection.Write.Create(&testAuditEntity{ID: 1}, "test_audit").GetError())
+		assert.NoError(t, connection.Write.SoftDelete(map[string]interface{}{"id": 1}, "test_audit").GetError())
+
+		result := connection.Read.FindAuditLogs("test_audit", nil)
+		assert.NoError(t, result.GetError())
+
+		logs := *result.GetData().(*[]audit.Log)
+		assert.Len(t, logs, 2)
+		assert.Equal(t, auditEnums.OperationDelete, logs[0].Operation)
+		assert.Contains(t, logs[0].Changes, auditEnums.ColumnDeletedAt)
+		assert.Contains(t, logs[0].Filter, "id")
+	})
+
+	t.Run("should return error when failed to soft delete", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE").WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+
+		assert.Error(t, database.SoftDelete(map[string]interface{}{"id": 1}, "test_audit").GetError())
+	})
+}
+
+func TestFindAuditLogs(t *testing.T) {
+	t.Run("should return the logs of all tables using the query", func(t *testing.T) {
+		connection := getTestAuditConnection(t)
+
+		assert.NoError(t, connection.Write.Create(&testAuditEntity{ID: 1}, "test_audit").GetError())
+		assert.NoError(t, connection.Write.Create(&testAuditEntity{ID: 2}, "test_audit").GetError())
+
+		result := connection.Read.FindAuditLogs("", query.New().Limit(1))
+		assert.NoError(t, result.GetError())
+		assert.Len(t, *result.GetData().(*[]audit.Log), 1)
+	})
+}
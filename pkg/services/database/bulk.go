@@ -0,0 +1,247 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/audit"
+	auditEnums "github.com/Fotkurz/horusec-devkit/pkg/services/database/audit/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant"
+	tenantEnums "github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant/enums"
+)
+
+// CreateInBatches inserts a slice of entities splitting it into multiple insert statements of at most batch size
+// rows, all of them inside the same transaction. When batch size is zero the default batch size is used. Like Create,
+// nothing is written when any of the entities is invalid.
+func (d *database) CreateInBatches(entitiesPointer interface{}, batchSize int, table string) response.IResponse {
+	if err := validate(entitiesPointer); err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	result := d.connectionWrite.Table(table).CreateInBatches(entitiesPointer, d.getBatchSize(batchSize))
+
+	return response.NewResponse(result.RowsAffected, result.Error, entitiesPointer)
+}
+
+// BulkUpsert inserts a slice of entities in batches, updating the existing rows that conflicts with the conflict
+// columns, like the repository_id and vuln_hash of a vulnerability. Only the update columns are changed on conflict,
+// or all of them when no update column is informed. The conflict columns must have an unique index. Like Create,
+// nothing is written when any of the entities is invalid.
+func (d *database) BulkUpsert(entitiesPointer interface{}, conflictColumns, updateColumns []string, batchSize int,
+	table string) response.IResponse {
+	if err := validate(entitiesPointer); err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	result := d.connectionWrite.Table(table).Clauses(d.getOnConflictClause(conflictColumns, updateColumns)).
+		CreateInBatches(entitiesPointer, d.getBatchSize(batchSize))
+
+	return response.NewResponse(result.RowsAffected, result.Error, entitiesPointer)
+}
+
+// CopyFrom inserts a slice of entities using the postgres COPY protocol, which is the fastest way to load a large
+// amount of rows. It uses a dedicated connection, so it is not available inside transactions. The entities are
+// validated and the tenant and audit columns are filled from the context like in Create, but the gorm hooks of the
+// entities are not run and no audit log is written for the copied rows.
+func (d *database) CopyFrom(entitiesPointer interface{}, table string) response.IResponse {
+	if d.isTransaction() {
+		return response.NewResponse(0, enums.ErrorCopyInsideTransaction, nil)
+	}
+
+	if err := validate(entitiesPointer); err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	ctx := d.getStatementContext()
+
+	columns, rows, err := d.getCopyRows(ctx, entitiesPointer)
+	if err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	copied, err := d.copyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), columns, rows)
+
+	return response.NewResponse(copied, err, entitiesPointer)
+}
+
+func (d *database) copyFrom(ctx context.Context, table pgx.Identifier, columns []string,
+	rows [][]interface{}) (copied int64, err error) {
+	sqlDB, err := d.connectionWrite.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	return copied, conn.Raw(func(driverConn interface{}) (err error) {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return enums.ErrorCopyNotSupported
+		}
+
+		copied, err = stdlibConn.Conn().CopyFrom(ctx, table, columns, pgx.CopyFromRows(rows))
+
+		return err
+	})
+}
+
+func (d *database) getCopyRows(ctx context.Context, entitiesPointer interface{}) (columns []string,
+	rows [][]interface{}, err error) {
+	items := reflect.Indirect(reflect.ValueOf(entitiesPointer))
+	if items.Kind() != reflect.Slice {
+		return nil, nil, enums.ErrorBulkRequiresSlice
+	}
+
+	entitySchema, err := schema.Parse(entitiesPointer, &sync.Map{}, d.connectionWrite.NamingStrategy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := d.getCopyFields(entitySchema)
+	for _, field := range fields {
+		columns = append(columns, field.DBName)
+	}
+
+	for index := 0; index < items.Len(); index++ {
+		rows = append(rows, d.getCopyRowValues(ctx, fields, reflect.Indirect(items.Index(index))))
+	}
+
+	return columns, rows, d.setCopyColumns(ctx, columns, rows)
+}
+
+func (d *database) getCopyFields(entitySchema *schema.Schema) (fields []*schema.Field) {
+	for _, dbName := range entitySchema.DBNames {
+		if field := entitySchema.FieldsByDBName[dbName]; field.Creatable {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+func (d *database) getCopyRowValues(ctx context.Context, fields []*schema.Field, item reflect.Value) []interface{} {
+	values := make([]interface{}, 0, len(fields))
+
+	for _, field := range fields {
+		value, _ := field.ValueOf(ctx, item)
+		if valuer, ok := value.(driver.Valuer); ok {
+			value, _ = valuer.Value()
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// setCopyColumns fills the tenant and audit columns of the rows from the context, as done by the tenant and audit
+// callbacks for the other writes, since COPY doesn't run the gorm callbacks.
+func (d *database) setCopyColumns(ctx context.Context, columns []string, rows [][]interface{}) error {
+	if err := d.setCopyTenantColumns(ctx, columns, rows); err != nil {
+		return err
+	}
+
+	d.setCopyColumn(columns, rows, auditEnums.ColumnCreatedBy, audit.GetAccountID(ctx))
+	d.setCopyColumn(columns, rows, auditEnums.ColumnUpdatedBy, audit.GetAccountID(ctx))
+
+	return nil
+}
+
+func (d *database) setCopyTenantColumns(ctx context.Context, columns []string, rows [][]interface{}) error {
+	if tenant.IsApplicationAdmin(ctx) {
+		return nil
+	}
+
+	scope, ok := tenant.GetScope(ctx)
+
+	for _, column := range tenantEnums.Columns {
+		if !ok && tenant.IsScopeRequired(ctx) && d.getColumnIndex(columns, column) >= 0 {
+			return tenantEnums.ErrorMissingTenantScope
+		}
+
+		d.setCopyColumn(columns, rows, column, scope.GetID(column))
+	}
+
+	return nil
+}
+
+func (d *database) setCopyColumn(columns []string, rows [][]interface{}, column string, id uuid.UUID) {
+	index := d.getColumnIndex(columns, column)
+	if index < 0 || id == uuid.Nil {
+		return
+	}
+
+	for _, row := range rows {
+		row[index] = id.String()
+	}
+}
+
+func (d *database) getColumnIndex(columns []string, column string) int {
+	for index, current := range columns {
+		if current == column {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// getStatementContext returns the context of the write connection, set by WithContext and WithTenant.
+func (d *database) getStatementContext() context.Context {
+	if d.connectionWrite.Statement.Context == nil {
+		return context.Background()
+	}
+
+	return d.connectionWrite.Statement.Context
+}
+
+func (d *database) getOnConflictClause(conflictColumns, updateColumns []string) clause.OnConflict {
+	columns := make([]clause.Column, 0, len(conflictColumns))
+	for _, column := range conflictColumns {
+		columns = append(columns, clause.Column{Name: column})
+	}
+
+	if len(updateColumns) == 0 {
+		return clause.OnConflict{Columns: columns, UpdateAll: true}
+	}
+
+	return clause.OnConflict{Columns: columns, DoUpdates: clause.AssignmentColumns(updateColumns)}
+}
+
+func (d *database) getBatchSize(batchSize int) int {
+	if batchSize <= 0 {
+		return enums.DefaultBatchSize
+	}
+
+	return batchSize
+}
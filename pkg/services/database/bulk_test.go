@@ -0,0 +1,259 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/audit"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/config"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant"
+	tenantEnums "github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant/enums"
+)
+
+type testBulkEntity struct {
+	RepositoryID uuid.UUID
+	VulnHash     string
+	Ignored      string `gorm:"-"`
+}
+
+type testBulkAuditedEntity struct {
+	VulnHash  string
+	CreatedBy uuid.UUID
+	UpdatedBy uuid.UUID
+}
+
+func newTestBulkEntities() *[]testBulkEntity {
+	return &[]testBulkEntity{
+		{RepositoryID: uuid.New(), VulnHash: "1"},
+		{RepositoryID: uuid.New(), VulnHash: "2"},
+		{RepositoryID: uuid.New(), VulnHash: "3"},
+	}
+}
+
+func TestCreateInBatches(t *testing.T) {
+	t.Run("should success create entities in batches", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "test" \("repository_id","vuln_hash"\) VALUES \(\$1,\$2\),\(\$3,\$4\)$`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`INSERT INTO "test" \("repository_id","vuln_hash"\) VALUES \(\$1,\$2\)$`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		database := &database{
+			config:          config.NewDatabaseConfig(),
+			connectionRead:  getMockedConnection(db),
+			connectionWrite: getMockedConnection(db),
+		}
+
+		entities := newTestBulkEntities()
+		response := database.CreateInBatches(entities, 2, "test")
+
+		assert.NoError(t, response.GetError())
+		assert.Equal(t, 3, response.GetRowsAffected())
+		assert.Equal(t, entities, response.GetData())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return error and create nothing when an entity is invalid", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+
+		response := database.CreateInBatches(&[]testValidatableEntity{{Name: "test", Code: "ok"}, {}}, 2, "test")
+
+		assert.Error(t, response.GetError())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBulkUpsert(t *testing.T) {
+	t.Run("should success upsert updating only informed columns", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "test" .* ON CONFLICT \("repository_id","vuln_hash"\) DO UPDATE SET ` +
+			`"vuln_hash"="excluded"."vuln_hash"`).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		database := &database{
+			config:          config.NewDatabaseConfig(),
+			connectionRead:  getMockedConnection(db),
+			connectionWrite: getMockedConnection(db),
+		}
+
+		response := database.BulkUpsert(newTestBulkEntities(), []string{"repository_id", "vuln_hash"},
+			[]string{"vuln_hash"}, 0, "test")
+
+		assert.NoError(t, response.GetError())
+		assert.Equal(t, 3, response.GetRowsAffected())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should success upsert updating all columns", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "test" .* ON CONFLICT \("vuln_hash"\) DO UPDATE SET ` +
+			`"repository_id"="excluded"."repository_id","vuln_hash"="excluded"."vuln_hash"`).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		database := &database{
+			config:          config.NewDatabaseConfig(),
+			connectionRead:  getMockedConnection(db),
+			connectionWrite: getMockedConnection(db),
+		}
+
+		response := database.BulkUpsert(newTestBulkEntities(), []string{"vuln_hash"}, nil, 10, "test")
+
+		assert.NoError(t, response.GetError())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return error and upsert nothing when an entity is invalid", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+
+		response := database.BulkUpsert(&[]testValidatableEntity{{}}, []string{"code"}, nil, 0, "test")
+
+		assert.Error(t, response.GetError())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCopyFrom(t *testing.T) {
+	t.Run("should return error when called inside transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+
+		response := database.StartTransaction().CopyFrom(newTestBulkEntities(), "test")
+
+		assert.Equal(t, enums.ErrorCopyInsideTransaction, response.GetError())
+	})
+
+	t.Run("should return error when entities are not a slice", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+
+		response := database.CopyFrom(&testBulkEntity{}, "test")
+
+		assert.Equal(t, enums.ErrorBulkRequiresSlice, response.GetError())
+	})
+
+	t.Run("should return error when an entity is invalid", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+
+		response := database.CopyFrom(&[]testValidatableEntity{{}}, "test")
+
+		assert.Error(t, response.GetError())
+		assert.NotEqual(t, enums.ErrorCopyNotSupported, response.GetError())
+	})
+
+	t.Run("should return error when the tenant scope is required but missing", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		database := (&database{connectionWrite: getMockedConnection(db)}).
+			withContext(tenant.WithRequiredScope(context.Background()))
+
+		response := database.CopyFrom(newTestBulkEntities(), "test")
+
+		assert.Equal(t, tenantEnums.ErrorMissingTenantScope, response.GetError())
+	})
+
+	t.Run("should return error when connection is not postgres", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+
+		response := database.CopyFrom(newTestBulkEntities(), "public.test")
+
+		assert.Equal(t, enums.ErrorCopyNotSupported, response.GetError())
+	})
+}
+
+func TestGetCopyRows(t *testing.T) {
+	t.Run("should return creatable columns and row values", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+		entities := newTestBulkEntities()
+
+		columns, rows, err := database.getCopyRows(context.Background(), entities)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"repository_id", "vuln_hash"}, columns)
+		assert.Len(t, rows, 3)
+		assert.Equal(t, []interface{}{(*entities)[0].RepositoryID.String(), "1"}, rows[0])
+	})
+
+	t.Run("should fill the tenant columns with the scope of the context", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+		repositoryID := uuid.New()
+		ctx := tenant.WithRequiredScope(tenant.WithScope(context.Background(), uuid.New(), repositoryID))
+
+		_, rows, err := database.getCopyRows(ctx, newTestBulkEntities())
+
+		assert.NoError(t, err)
+		for _, row := range rows {
+			assert.Equal(t, repositoryID.String(), row[0])
+		}
+	})
+
+	t.Run("should fill the audit columns with the account of the context", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+		accountID := uuid.New()
+
+		columns, rows, err := database.getCopyRows(audit.WithAccountID(context.Background(), accountID),
+			&[]testBulkAuditedEntity{{VulnHash: "1"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"vuln_hash", "created_by", "updated_by"}, columns)
+		assert.Equal(t, []interface{}{"1", accountID.String(), accountID.String()}, rows[0])
+	})
+}
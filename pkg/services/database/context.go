@@ -0,0 +1,78 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import "context"
+
+// WithContext returns a copy of the connection whose reads and writes use the context, which carries the request id
+// of the router to the query logs and the account of audit.WithAccountID to the audit columns. Connections not
+// created by NewDatabaseReadAndWrite, like the ones using mocks, are returned as they are.
+// Usage example: connection.WithContext(r.Context()).Read.Find(...)
+func (c *Connection) WithContext(ctx context.Context) *Connection {
+	if c.database == nil {
+		return c
+	}
+
+	return c.database.withContext(ctx).setConnections()
+}
+
+// WithContext returns a copy of the write connection using the context, which could carry the account responsible
+// for the changes through audit.WithAccountID.
+func (d *database) WithContext(ctx context.Context) IDatabaseWrite {
+	return d.withContext(ctx)
+}
+
+func (d *database) withContext(ctx context.Context) *database {
+	database := &database{
+		connectionWrite: d.connectionWrite,
+		connectionRead:  d.connectionRead,
+		replicas:        d.replicas,
+		config:          d.config,
+		ctx:             ctx,
+	}
+
+	if d.connectionWrite != nil {
+		database.connectionWrite = d.connectionWrite.WithContext(ctx)
+	}
+
+	return database
+}
+
+// mergedContext is a context canceled by the context of the caller, whose values are searched on it and then on the
+// context of the handle, so the values of the handle like the tenant scope are not lost.
+type mergedContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c *mergedContext) Value(key interface{}) interface{} {
+	if value := c.Context.Value(key); value != nil {
+		return value
+	}
+
+	return c.values.Value(key)
+}
+
+func (d *database) mergeContext(ctx context.Context) context.Context {
+	if d.ctx == nil || d.ctx == ctx {
+		return ctx
+	}
+
+	if ctx == nil {
+		return d.ctx
+	}
+
+	return &mergedContext{Context: ctx, values: d.ctx}
+}
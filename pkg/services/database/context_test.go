@@ -0,0 +1,55 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/middleware"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+func TestConnectionWithContext(t *testing.T) {
+	t.Run("should use the context on reads and writes", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		replica := getMockedConnection(db)
+		connection := (&database{
+			connectionWrite: getMockedConnection(db),
+			connectionRead:  replica,
+			replicas:        newReplicaRouter([]*gorm.DB{replica}, enums.ReadRoutingRoundRobin),
+		}).setConnections()
+
+		ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "test")
+		connection = connection.WithContext(ctx)
+
+		contextDatabase := connection.Read.(*database)
+		assert.Equal(t, ctx, contextDatabase.getConnectionRead().Statement.Context)
+		assert.Equal(t, ctx, contextDatabase.connectionWrite.Statement.Context)
+		assert.Same(t, contextDatabase, connection.Write)
+	})
+
+	t.Run("should return the same connection when not created by the database service", func(t *testing.T) {
+		connection := &Connection{Read: &Mock{}, Write: &Mock{}}
+
+		assert.Same(t, connection, connection.WithContext(context.Background()))
+	})
+}
@@ -15,29 +15,37 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"reflect"
 	"strings"
 
-	"gorm.io/driver/postgres"
+	"github.com/prometheus/client_golang/prometheus"
 	"gorm.io/gorm"
-	gormLogger "gorm.io/gorm/logger"
 
 	databaseConfig "github.com/Fotkurz/horusec-devkit/pkg/services/database/config"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/dialect"
 	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/query"
 	"github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tracing"
 	"github.com/Fotkurz/horusec-devkit/pkg/utils/logger"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/pagination"
 )
 
 type (
 	database struct {
 		connectionWrite *gorm.DB
 		connectionRead  *gorm.DB
+		replicas        *replicaRouter
 		config          databaseConfig.IConfig
+		ctx             context.Context
 	}
 
 	Connection struct {
-		Read  IDatabaseRead
-		Write IDatabaseWrite
+		Read     IDatabaseRead
+		Write    IDatabaseWrite
+		database *database
 	}
 )
 
@@ -50,13 +58,31 @@ func NewDatabaseReadAndWrite(config databaseConfig.IConfig) (*Connection, error)
 	database.makeConnection()
 	database.setLogMode()
 
+	if err := database.setCallbacks(); err != nil {
+		return nil, err
+	}
+
 	return database.setConnections(), nil
 }
 
+// setCallbacks registers the tracing, audit and tenant callbacks into the connections.
+func (d *database) setCallbacks() error {
+	if err := d.setTracing(); err != nil {
+		return err
+	}
+
+	if err := d.setAudit(); err != nil {
+		return err
+	}
+
+	return d.setTenant()
+}
+
 func (d *database) setConnections() *Connection {
 	return &Connection{
-		Read:  d,
-		Write: d,
+		Read:     d,
+		Write:    d,
+		database: d,
 	}
 }
 
@@ -66,38 +92,91 @@ func (d *database) makeConnection() {
 }
 
 func (d *database) makeConnectionWrite() {
-	connectionWrite, err := gorm.Open(postgres.Open(d.config.GetURI()), &gorm.Config{})
-	if err != nil {
-		logger.LogPanic(enums.MessageFailedToConnectToDatabase, enums.ErrorConnectingToDB)
+	d.connectionWrite = d.openConnection(d.config.GetURI())
+}
+
+func (d *database) makeConnectionRead() {
+	connections := make([]*gorm.DB, 0, len(d.config.GetReadURIs()))
+	for _, uri := range d.config.GetReadURIs() {
+		connections = append(connections, d.openConnection(uri))
 	}
 
-	d.connectionWrite = connectionWrite
+	d.connectionRead = connections[0]
+	d.replicas = newReplicaRouter(connections, d.config.GetReadRoutingPolicy())
 }
 
-func (d *database) makeConnectionRead() {
-	connectionRead, err := gorm.Open(postgres.Open(d.config.GetURI()), &gorm.Config{})
+func (d *database) openConnection(uri string) *gorm.DB {
+	dialector, err := dialect.Open(uri)
+	if err != nil {
+		logger.LogPanic(enums.MessageFailedToConnectToDatabase, err)
+	}
+
+	connection, err := gorm.Open(dialector, &gorm.Config{})
 	if err != nil {
 		logger.LogPanic(enums.MessageFailedToConnectToDatabase, enums.ErrorConnectingToDB)
 	}
 
-	d.connectionRead = connectionRead
+	d.setPoolConfig(connection)
+
+	return connection
 }
 
 func (d *database) setLogMode() {
-	if d.config.GetLogMode() {
-		d.connectionWrite.Logger = d.connectionWrite.Logger.LogMode(gormLogger.Info)
-		d.connectionRead.Logger = d.connectionRead.Logger.LogMode(gormLogger.Info)
+	queryLogger := tracing.NewLogger(d.config.GetLogMode(), d.config.GetSlowQueryThreshold())
 
-		return
+	d.connectionWrite.Logger = queryLogger
+	for _, connection := range d.getReadConnections() {
+		connection.Logger = queryLogger
 	}
+}
 
-	d.connectionWrite.Logger = d.connectionWrite.Logger.LogMode(gormLogger.Error)
-	d.connectionRead.Logger = d.connectionRead.Logger.LogMode(gormLogger.Error)
+// setTracing exports the duration of the queries made by all connections to the prometheus default registerer,
+// which is the one served by the router metrics route.
+func (d *database) setTracing() error {
+	if err := d.connectionWrite.Use(tracing.NewPlugin(prometheus.DefaultRegisterer)); err != nil {
+		return err
+	}
+
+	for _, connection := range d.getReadConnections() {
+		if err := connection.Use(tracing.NewPlugin(prometheus.DefaultRegisterer)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getConnectionRead returns the read replica chosen by the routing policy, or the single read connection when no
+// replicas are configured.
+func (d *database) getConnectionRead() *gorm.DB {
+	connection := d.connectionRead
+	if d.replicas != nil {
+		connection = d.replicas.next()
+	}
+
+	if d.ctx != nil {
+		return connection.WithContext(d.ctx)
+	}
+
+	return connection
+}
+
+func (d *database) getReadConnections() []*gorm.DB {
+	if d.replicas == nil {
+		return []*gorm.DB{d.connectionRead}
+	}
+
+	return d.replicas.connections
 }
 
 func (d *database) StartTransaction() IDatabaseWrite {
+	tx := d.connectionWrite.Begin()
+
 	return &database{
-		connectionWrite: d.connectionWrite.Begin(),
+		connectionWrite: tx,
+		connectionRead:  tx,
+		config:          d.config,
+		ctx:             d.ctx,
 	}
 }
 
@@ -122,7 +201,13 @@ func (d *database) IsAvailable() bool {
 		return false
 	}
 
-	return d.pingDatabase(d.connectionRead.DB())
+	for _, connection := range d.getReadConnections() {
+		if !d.pingDatabase(connection.DB()) {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (d *database) pingDatabase(db *sql.DB, err error) bool {
@@ -136,6 +221,10 @@ func (d *database) pingDatabase(db *sql.DB, err error) bool {
 }
 
 func (d *database) Create(entityPointer interface{}, table string) response.IResponse {
+	if err := validate(entityPointer); err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
 	result := d.connectionWrite.Table(table).Create(entityPointer)
 
 	return response.NewResponse(result.RowsAffected, result.Error, entityPointer)
@@ -143,13 +232,17 @@ func (d *database) Create(entityPointer interface{}, table string) response.IRes
 
 func (d *database) CreateOrUpdate(entityPointer interface{}, where map[string]interface{},
 	table string) response.IResponse {
+	if err := validate(entityPointer); err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
 	result := d.connectionWrite.Table(table).Where(where).Save(entityPointer)
 
 	return response.NewResponse(result.RowsAffected, result.Error, entityPointer)
 }
 
 func (d *database) Find(entityPointer interface{}, where map[string]interface{}, table string) response.IResponse {
-	result := d.connectionRead.Table(table).Where(where).Find(entityPointer)
+	result := d.getConnectionRead().Table(table).Where(where).Find(entityPointer)
 	if err := d.verifyNotFoundError(result); err != nil {
 		return response.NewResponse(0, err, nil)
 	}
@@ -158,6 +251,10 @@ func (d *database) Find(entityPointer interface{}, where map[string]interface{},
 }
 
 func (d *database) Update(entityPointer interface{}, where map[string]interface{}, table string) response.IResponse {
+	if err := validatePartial(entityPointer); err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
 	result := d.connectionWrite.Table(table).Where(where).Updates(entityPointer)
 
 	return response.NewResponse(result.RowsAffected, result.Error, entityPointer)
@@ -170,7 +267,7 @@ func (d *database) Delete(where map[string]interface{}, table string) response.I
 }
 
 func (d *database) First(entityPointer interface{}, where map[string]interface{}, table string) response.IResponse {
-	result := d.connectionRead.Table(table).Where(where).First(entityPointer)
+	result := d.getConnectionRead().Table(table).Where(where).First(entityPointer)
 	if err := d.verifyNotFoundError(result); err != nil {
 		return response.NewResponse(0, err, nil)
 	}
@@ -179,7 +276,7 @@ func (d *database) First(entityPointer interface{}, where map[string]interface{}
 }
 
 func (d *database) Raw(rawSQL string, entityPointer interface{}, values ...interface{}) response.IResponse {
-	result := d.connectionRead.Raw(rawSQL, values...).Scan(entityPointer)
+	result := d.getConnectionRead().Raw(rawSQL, values...).Scan(entityPointer)
 	if err := d.verifyNotFoundError(result); err != nil {
 		return response.NewResponse(0, err, nil)
 	}
@@ -206,7 +303,7 @@ func (d *database) verifyNotFoundError(result *gorm.DB) error {
 
 func (d *database) FindPreload(entityPointer interface{}, where map[string]interface{},
 	preloads map[string][]interface{}, table string) response.IResponse {
-	query := d.connectionRead.Table(table).Where(where)
+	query := d.getConnectionRead().Table(table).Where(where)
 	for key, preload := range preloads {
 		query = query.Preload(key, preload...)
 	}
@@ -238,10 +335,128 @@ func (d *database) FindPreloadWitLimitAndPage(entityPointer interface{}, where m
 func (d *database) findPreloadWitLimitAndPageQuery(
 	table string, where map[string]interface{}, limit, page int) *gorm.DB {
 	if limit == 0 {
-		limit = 10
+		limit = enums.DefaultPageSize
+	}
+
+	return d.getConnectionRead().Table(table).Where(where).Limit(limit).Offset(page * limit)
+}
+
+// applyQuery applies the query into the read connection, where a nil query reads all the records of the table.
+func (d *database) applyQuery(spec *query.Query, table string) (*gorm.DB, error) {
+	if spec == nil {
+		spec = query.New()
+	}
+
+	return spec.Apply(d.getConnectionRead().Table(table))
+}
+
+func (d *database) FindByQuery(entityPointer interface{}, spec *query.Query, table string) response.IResponse {
+	statement, err := d.applyQuery(spec, table)
+	if err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	result := statement.Find(entityPointer)
+	if err = d.verifyNotFoundError(result); err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	return response.NewResponse(result.RowsAffected, result.Error, entityPointer)
+}
+
+func (d *database) FirstByQuery(entityPointer interface{}, spec *query.Query, table string) response.IResponse {
+	statement, err := d.applyQuery(spec, table)
+	if err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	result := statement.First(entityPointer)
+	if err = d.verifyNotFoundError(result); err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	return response.NewResponse(result.RowsAffected, result.Error, entityPointer)
+}
+
+// FindByCursor uses keyset pagination to get the page after the cursor, which is faster and more consistent than
+// offset pagination on large tables. The query must be ordered by a unique combination of fields and the entity
+// pointer must be a pointer to slice. The response data is a pagination.CursorPage with the next cursor.
+func (d *database) FindByCursor(entityPointer interface{}, spec *query.Query, cursor string, size int,
+	table string) response.IResponse {
+	if spec == nil {
+		spec = query.New()
+	}
+
+	statement, err := d.findByCursorQuery(spec, cursor, size, table)
+	if err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	result := statement.Find(entityPointer)
+	if err = d.verifyNotFoundError(result); err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	return d.newCursorPageResponse(result, spec, entityPointer, d.getPageSize(size))
+}
+
+func (d *database) findByCursorQuery(spec *query.Query, cursor string, size int, table string) (*gorm.DB, error) {
+	if len(spec.GetOrders()) == 0 {
+		return nil, enums.ErrorInvalidQuerySeek
+	}
+
+	values, err := pagination.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return spec.Clone().Seek(values...).Limit(d.getPageSize(size) + 1).Apply(d.getConnectionRead().Table(table))
+}
+
+func (d *database) getPageSize(size int) int {
+	if size <= 0 {
+		return enums.DefaultPageSize
+	}
+
+	return size
+}
+
+func (d *database) newCursorPageResponse(result *gorm.DB, spec *query.Query, entityPointer interface{},
+	size int) response.IResponse {
+	items := reflect.Indirect(reflect.ValueOf(entityPointer))
+	if items.Kind() != reflect.Slice {
+		return response.NewResponse(0, enums.ErrorInvalidCursorEntity, nil)
+	}
+
+	if items.Len() <= size {
+		return response.NewResponse(int64(items.Len()), nil, &pagination.CursorPage{Items: entityPointer})
+	}
+
+	items.Set(items.Slice(0, size))
+
+	nextCursor, err := d.getNextCursor(result, spec, reflect.Indirect(items.Index(size-1)))
+	if err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	return response.NewResponse(int64(size), nil,
+		&pagination.CursorPage{Items: entityPointer, NextCursor: nextCursor, HasMore: true})
+}
+
+func (d *database) getNextCursor(result *gorm.DB, spec *query.Query, last reflect.Value) (string, error) {
+	values := make([]interface{}, 0, len(spec.GetOrders()))
+
+	for _, order := range spec.GetOrders() {
+		field := result.Statement.Schema.LookUpField(order.Field[strings.LastIndex(order.Field, ".")+1:])
+		if field == nil {
+			return "", enums.ErrorInvalidQueryField
+		}
+
+		value, _ := field.ValueOf(result.Statement.Context, last)
+		values = append(values, value)
 	}
 
-	return d.connectionRead.Table(table).Where(where).Limit(limit).Offset(page * limit)
+	return pagination.EncodeCursor(values...)
 }
 
 // Deprecated: Exec starts a transaction and try to execute the raw query into database.
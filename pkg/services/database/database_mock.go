@@ -15,10 +15,12 @@
 package database
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/stretchr/testify/mock"
 
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/query"
 	"github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
 	mockUtils "github.com/Fotkurz/horusec-devkit/pkg/utils/mock"
 )
@@ -62,6 +64,22 @@ func (m *Mock) CommitTransaction() response.IResponse {
 	return args.Get(0).(response.IResponse)
 }
 
+// WithTransaction executes the function using the mock itself as the transaction when no error is configured.
+func (m *Mock) WithTransaction(_ context.Context, fn func(tx IDatabaseWrite) error) error {
+	args := m.MethodCalled("WithTransaction")
+	if err := mockUtils.ReturnNilOrError(args, 0); err != nil {
+		return err
+	}
+
+	return fn(m)
+}
+
+// WithContext returns the mock itself, so the expectations configured are also used by the writes with context.
+func (m *Mock) WithContext(_ context.Context) IDatabaseWrite {
+	_ = m.MethodCalled("WithContext")
+	return m
+}
+
 func (m *Mock) Create(_ interface{}, _ string) response.IResponse {
 	args := m.MethodCalled("Create")
 	return args.Get(0).(response.IResponse)
@@ -82,6 +100,26 @@ func (m *Mock) Delete(_ map[string]interface{}, _ string) response.IResponse {
 	return args.Get(0).(response.IResponse)
 }
 
+func (m *Mock) SoftDelete(_ map[string]interface{}, _ string) response.IResponse {
+	args := m.MethodCalled("SoftDelete")
+	return args.Get(0).(response.IResponse)
+}
+
+func (m *Mock) CreateInBatches(_ interface{}, _ int, _ string) response.IResponse {
+	args := m.MethodCalled("CreateInBatches")
+	return args.Get(0).(response.IResponse)
+}
+
+func (m *Mock) BulkUpsert(_ interface{}, _, _ []string, _ int, _ string) response.IResponse {
+	args := m.MethodCalled("BulkUpsert")
+	return args.Get(0).(response.IResponse)
+}
+
+func (m *Mock) CopyFrom(_ interface{}, _ string) response.IResponse {
+	args := m.MethodCalled("CopyFrom")
+	return args.Get(0).(response.IResponse)
+}
+
 func (m *Mock) Exec(_ string, _ ...interface{}) error {
 	args := m.MethodCalled("Exec")
 	return mockUtils.ReturnNilOrError(args, 0)
@@ -98,6 +136,37 @@ func (m *Mock) FindPreloadWitLimitAndPage(entityPointer interface{}, _ map[strin
 	return m.reflectValues(entityPointer, args.Get(0).(response.IResponse))
 }
 
+func (m *Mock) FindByQuery(entityPointer interface{}, _ *query.Query, _ string) response.IResponse {
+	args := m.MethodCalled("FindByQuery")
+	return m.reflectValues(entityPointer, args.Get(0).(response.IResponse))
+}
+
+func (m *Mock) FirstByQuery(entityPointer interface{}, _ *query.Query, _ string) response.IResponse {
+	args := m.MethodCalled("FirstByQuery")
+	return m.reflectValues(entityPointer, args.Get(0).(response.IResponse))
+}
+
+func (m *Mock) FindByCursor(_ interface{}, _ *query.Query, _ string, _ int, _ string) response.IResponse {
+	args := m.MethodCalled("FindByCursor")
+	return args.Get(0).(response.IResponse)
+}
+
+func (m *Mock) FindAuditLogs(_ string, _ *query.Query) response.IResponse {
+	args := m.MethodCalled("FindAuditLogs")
+	return args.Get(0).(response.IResponse)
+}
+
+func (m *Mock) Iterate(_ interface{}, _ *query.Query, _ string, _ func() error) response.IResponse {
+	args := m.MethodCalled("Iterate")
+	return args.Get(0).(response.IResponse)
+}
+
+func (m *Mock) FindInBatches(_ interface{}, _ *query.Query, _ string, _ int,
+	_ func(batch int) error) response.IResponse {
+	args := m.MethodCalled("FindInBatches")
+	return args.Get(0).(response.IResponse)
+}
+
 func (m *Mock) reflectValues(entityPointer interface{}, resp response.IResponse) response.IResponse {
 	bytes, _ := json.Marshal(resp.GetData())
 	_ = json.Unmarshal(bytes, entityPointer)
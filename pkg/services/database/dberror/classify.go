@@ -0,0 +1,143 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dberror
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/glebarez/go-sqlite"
+	"github.com/jackc/pgconn"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+// Classify maps postgres and sqlite driver errors to an *Error of the matching kind. Errors already classified or
+// not recognized are returned as they are. Deadlines and cancellations of the context are checked before the network
+// errors, since a context.DeadlineExceeded is also a net.Error, so they are ErrTimeout and ErrCanceled instead of
+// ErrUnavailable.
+func Classify(err error) error {
+	var classified *Error
+	if err == nil || errors.As(err, &classified) {
+		return err
+	}
+
+	var pgError *pgconn.PgError
+	if errors.As(err, &pgError) {
+		return newError(classifyPostgres(pgError.Code), pgError.ConstraintName, err)
+	}
+
+	var sqliteError *sqlite.Error
+	if errors.As(err, &sqliteError) {
+		return newError(classifySQLite(sqliteError), getSQLiteConstraint(sqliteError), err)
+	}
+
+	if kind := classifyContext(err); kind != nil {
+		return newError(kind, "", err)
+	}
+
+	if isConnectionError(err) {
+		return newError(ErrUnavailable, "", err)
+	}
+
+	return err
+}
+
+func newError(kind error, constraint string, err error) error {
+	if kind == nil {
+		return err
+	}
+
+	return &Error{Kind: kind, Constraint: constraint, Err: err}
+}
+
+func classifyPostgres(code string) error {
+	switch code {
+	case enums.SQLStateUniqueViolation:
+		return ErrDuplicate
+	case enums.SQLStateForeignKeyViolation:
+		return ErrForeignKey
+	case enums.SQLStateSerializationFailure, enums.SQLStateDeadlockDetected:
+		return ErrConflict
+	case enums.SQLStateTooManyConnections, enums.SQLStateAdminShutdown, enums.SQLStateCrashShutdown,
+		enums.SQLStateCannotConnectNow:
+		return ErrUnavailable
+	}
+
+	if strings.HasPrefix(code, enums.SQLStateClassConnectionException) {
+		return ErrUnavailable
+	}
+
+	return nil
+}
+
+// classifySQLite classifies the extended result code of the sqlite errors, falling back to its primary code.
+func classifySQLite(sqliteError *sqlite.Error) error {
+	switch sqliteError.Code() {
+	case enums.SQLiteCodeConstraintUnique, enums.SQLiteCodeConstraintPrimaryKey:
+		return ErrDuplicate
+	case enums.SQLiteCodeConstraintForeignKey:
+		return ErrForeignKey
+	}
+
+	switch sqliteError.Code() & enums.SQLiteCodeMask {
+	case enums.SQLiteCodeBusy, enums.SQLiteCodeLocked:
+		return ErrConflict
+	case enums.SQLiteCodeCantOpen, enums.SQLiteCodeIOError:
+		return ErrUnavailable
+	}
+
+	return nil
+}
+
+// getSQLiteConstraint returns the columns of the violated constraint, since sqlite messages like
+// "constraint failed: UNIQUE constraint failed: analysis.id (2067)" don't contain the constraint name.
+func getSQLiteConstraint(sqliteError *sqlite.Error) string {
+	if sqliteError.Code()&enums.SQLiteCodeMask != enums.SQLiteCodeConstraint {
+		return ""
+	}
+
+	message := sqliteError.Error()
+	if index := strings.LastIndex(message, " ("); index >= 0 {
+		message = message[:index]
+	}
+
+	if index := strings.LastIndex(message, ": "); index >= 0 {
+		return message[index+len(": "):]
+	}
+
+	return ""
+}
+
+func classifyContext(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrCanceled
+	}
+
+	return nil
+}
+
+func isConnectionError(err error) bool {
+	var netError net.Error
+
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.As(err, &netError)
+}
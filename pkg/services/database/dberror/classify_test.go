@@ -0,0 +1,121 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dberror
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+func TestClassifyPostgres(t *testing.T) {
+	t.Run("should classify the postgres errors by sql state", func(t *testing.T) {
+		codes := map[string]error{
+			enums.SQLStateUniqueViolation:      ErrDuplicate,
+			enums.SQLStateForeignKeyViolation:  ErrForeignKey,
+			enums.SQLStateSerializationFailure: ErrConflict,
+			enums.SQLStateDeadlockDetected:     ErrConflict,
+			enums.SQLStateTooManyConnections:   ErrUnavailable,
+			enums.SQLStateCannotConnectNow:     ErrUnavailable,
+			"08006":                            ErrUnavailable,
+		}
+
+		for code, kind := range codes {
+			err := Classify(fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: code, ConstraintName: "analysis_pkey"}))
+
+			assert.ErrorIs(t, err, kind, code)
+			assert.Equal(t, "analysis_pkey", GetConstraint(err))
+		}
+	})
+
+	t.Run("should keep the postgres errors not classified", func(t *testing.T) {
+		pgError := &pgconn.PgError{Code: "42P01"}
+
+		assert.Equal(t, pgError, Classify(pgError))
+	})
+}
+
+func TestClassifySQLite(t *testing.T) {
+	connection, err := gorm.Open(sqlite.Open("file::memory:?_pragma=foreign_keys(1)"), &gorm.Config{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, connection.Exec("CREATE TABLE workspace (id INTEGER PRIMARY KEY, name TEXT UNIQUE)").Error)
+	assert.NoError(t, connection.Exec("CREATE TABLE repository (id INTEGER PRIMARY KEY, "+
+		"workspace_id INTEGER REFERENCES workspace(id))").Error)
+	assert.NoError(t, connection.Exec("INSERT INTO workspace VALUES (1, 'test')").Error)
+
+	t.Run("should classify the sqlite unique constraint errors", func(t *testing.T) {
+		err := Classify(connection.Exec("INSERT INTO workspace VALUES (2, 'test')").Error)
+
+		assert.ErrorIs(t, err, ErrDuplicate)
+		assert.Equal(t, "workspace.name", GetConstraint(err))
+	})
+
+	t.Run("should classify the sqlite primary key errors", func(t *testing.T) {
+		assert.ErrorIs(t, Classify(connection.Exec("INSERT INTO workspace VALUES (1, 'other')").Error), ErrDuplicate)
+	})
+
+	t.Run("should classify the sqlite foreign key errors", func(t *testing.T) {
+		err := Classify(connection.Exec("INSERT INTO repository VALUES (1, 2)").Error)
+
+		assert.ErrorIs(t, err, ErrForeignKey)
+	})
+
+	t.Run("should keep the sqlite errors not classified", func(t *testing.T) {
+		err := connection.Exec("SELECT * FROM analysis").Error
+
+		assert.Equal(t, err, Classify(err))
+	})
+}
+
+func TestClassify(t *testing.T) {
+	t.Run("should return nil when error is nil", func(t *testing.T) {
+		assert.NoError(t, Classify(nil))
+	})
+
+	t.Run("should classify connection errors as unavailable", func(t *testing.T) {
+		assert.ErrorIs(t, Classify(driver.ErrBadConn), ErrUnavailable)
+		assert.ErrorIs(t, Classify(&net.OpError{Op: "dial", Err: errors.New("connection refused")}), ErrUnavailable)
+	})
+
+	t.Run("should classify context errors as timeout and canceled instead of unavailable", func(t *testing.T) {
+		assert.ErrorIs(t, Classify(context.DeadlineExceeded), ErrTimeout)
+		assert.ErrorIs(t, Classify(&net.OpError{Op: "read", Err: context.DeadlineExceeded}), ErrTimeout)
+		assert.ErrorIs(t, Classify(fmt.Errorf("test: %w", context.Canceled)), ErrCanceled)
+		assert.NotErrorIs(t, Classify(context.DeadlineExceeded), ErrUnavailable)
+	})
+
+	t.Run("should return the errors already classified as they are", func(t *testing.T) {
+		err := &Error{Kind: ErrDuplicate}
+
+		assert.Same(t, err, Classify(err))
+	})
+
+	t.Run("should return the unknown errors as they are", func(t *testing.T) {
+		err := errors.New("test")
+
+		assert.Equal(t, err, Classify(err))
+	})
+}
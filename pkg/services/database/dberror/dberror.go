@@ -0,0 +1,64 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dberror
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrDuplicate   = errors.New("{ERROR_DATABASE} record already exists")
+	ErrForeignKey  = errors.New("{ERROR_DATABASE} record references or is referenced by another record")
+	ErrConflict    = errors.New("{ERROR_DATABASE} record changed by a concurrent transaction, try again")
+	ErrUnavailable = errors.New("{ERROR_DATABASE} database is unavailable")
+	ErrTimeout     = errors.New("{ERROR_DATABASE} database operation exceeded the deadline of the context")
+	ErrCanceled    = errors.New("{ERROR_DATABASE} database operation canceled by the context")
+)
+
+// Error is a classified database error, where Kind is one of the errors of this package, like ErrDuplicate, and
+// Constraint the name of the violated constraint when known. The driver error is kept and could be unwrapped.
+// Usage example: errors.Is(response.GetError(), dberror.ErrDuplicate)
+type Error struct {
+	Kind       error
+	Constraint string
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Constraint == "" {
+		return e.Kind.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", e.Kind.Error(), e.Constraint)
+}
+
+func (e *Error) Is(target error) bool {
+	return e.Kind == target
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// GetConstraint returns the constraint name of a classified error, or an empty string when unknown.
+func GetConstraint(err error) string {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.Constraint
+	}
+
+	return ""
+}
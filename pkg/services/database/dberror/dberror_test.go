@@ -0,0 +1,49 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dberror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	t.Run("should return the kind message with the constraint", func(t *testing.T) {
+		err := &Error{Kind: ErrDuplicate, Constraint: "analysis_pkey", Err: errors.New("test")}
+
+		assert.Equal(t, ErrDuplicate.Error()+": analysis_pkey", err.Error())
+	})
+
+	t.Run("should return the kind message when constraint is unknown", func(t *testing.T) {
+		assert.Equal(t, ErrConflict.Error(), (&Error{Kind: ErrConflict}).Error())
+	})
+
+	t.Run("should match the kind and unwrap the driver error", func(t *testing.T) {
+		driverError := errors.New("test")
+		err := &Error{Kind: ErrForeignKey, Err: driverError}
+
+		assert.ErrorIs(t, err, ErrForeignKey)
+		assert.ErrorIs(t, err, driverError)
+		assert.NotErrorIs(t, err, ErrDuplicate)
+	})
+}
+
+func TestGetConstraint(t *testing.T) {
+	t.Run("should return empty when error is not classified", func(t *testing.T) {
+		assert.Empty(t, GetConstraint(errors.New("test")))
+	})
+}
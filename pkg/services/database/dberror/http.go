@@ -0,0 +1,71 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dberror
+
+import (
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	httpUtil "github.com/Fotkurz/horusec-devkit/pkg/utils/http"
+)
+
+// GetHTTPStatus returns the http status code matching the database error, being bad request for invalid entities,
+// not found for records not found, conflict for duplicated records and concurrent changes, unprocessable entity for
+// foreign key violations, service unavailable when the database is unavailable, gateway timeout when the deadline of
+// the context is exceeded and internal server error for the others, including the canceled ones.
+func GetHTTPStatus(err error) int {
+	var validationErrors validation.Errors
+
+	switch {
+	case errors.As(err, &validationErrors):
+		return http.StatusBadRequest
+	case errors.Is(err, enums.ErrorNotFoundRecords):
+		return http.StatusNotFound
+	case errors.Is(err, ErrDuplicate), errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrForeignKey):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrTimeout):
+		return http.StatusGatewayTimeout
+	}
+
+	return http.StatusInternalServerError
+}
+
+// StatusResponse writes the utils/http response matching the database error, see GetHTTPStatus.
+// Usage example: if err := result.GetError(); err != nil { dberror.StatusResponse(w, err) }
+func StatusResponse(w http.ResponseWriter, err error) {
+	switch GetHTTPStatus(err) {
+	case http.StatusBadRequest:
+		httpUtil.StatusBadRequest(w, err)
+	case http.StatusNotFound:
+		httpUtil.StatusNotFound(w, err)
+	case http.StatusConflict:
+		httpUtil.StatusConflict(w, err)
+	case http.StatusUnprocessableEntity:
+		httpUtil.StatusUnprocessableEntity(w, err)
+	case http.StatusServiceUnavailable:
+		httpUtil.StatusServiceUnavailable(w, err)
+	case http.StatusGatewayTimeout:
+		httpUtil.StatusGatewayTimeout(w, err)
+	default:
+		httpUtil.StatusInternalServerError(w, err)
+	}
+}
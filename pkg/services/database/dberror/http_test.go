@@ -0,0 +1,61 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dberror
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+func TestStatusResponse(t *testing.T) {
+	t.Run("should write the status matching the database error", func(t *testing.T) {
+		statuses := map[error]int{
+			enums.ErrorNotFoundRecords:   http.StatusNotFound,
+			&Error{Kind: ErrDuplicate}:   http.StatusConflict,
+			&Error{Kind: ErrConflict}:    http.StatusConflict,
+			&Error{Kind: ErrForeignKey}:  http.StatusUnprocessableEntity,
+			&Error{Kind: ErrUnavailable}: http.StatusServiceUnavailable,
+			&Error{Kind: ErrTimeout}:     http.StatusGatewayTimeout,
+			&Error{Kind: ErrCanceled}:    http.StatusInternalServerError,
+			errors.New("unknown"):        http.StatusInternalServerError,
+		}
+
+		for err, status := range statuses {
+			w := httptest.NewRecorder()
+
+			StatusResponse(w, err)
+
+			assert.Equal(t, status, w.Code, err.Error())
+			assert.Equal(t, status, GetHTTPStatus(err))
+		}
+	})
+
+	t.Run("should write bad request when invalid entity", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := validation.Errors{"severity": errors.New("must be a valid value")}
+
+		StatusResponse(w, err)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusBadRequest, GetHTTPStatus(err))
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialect
+
+import (
+	"strings"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+// GetDialect returns the dialect of the database uri by its scheme. Uris without scheme, like the key value format
+// "host=localhost user=root", are considered postgres ones to keep compatibility with previous configurations.
+func GetDialect(uri string) (string, error) {
+	if strings.HasPrefix(uri, enums.SQLiteFilePrefix) {
+		return enums.DialectSQLite, nil
+	}
+
+	index := strings.Index(uri, enums.SchemeSeparator)
+	if index < 0 {
+		return enums.DialectPostgres, nil
+	}
+
+	switch strings.ToLower(uri[:index]) {
+	case enums.SchemePostgres, enums.SchemePostgresql:
+		return enums.DialectPostgres, nil
+	case enums.SchemeSQLite, enums.SchemeSQLite3:
+		return enums.DialectSQLite, nil
+	}
+
+	return "", enums.ErrorUnsupportedDialect
+}
+
+// Open returns the gorm dialector of the database uri. Sqlite uris could be "sqlite://horusec.db",
+// "sqlite://:memory:" or a "file:" uri accepted by the driver, like "file::memory:?cache=shared", which is the
+// one needed to share an in memory database between the read and write connections. The sqlite driver is pure go,
+// so it works in binaries built without cgo.
+func Open(uri string) (gorm.Dialector, error) {
+	dialect, err := GetDialect(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if dialect == enums.DialectSQLite {
+		return sqlite.Open(getSQLiteDSN(uri)), nil
+	}
+
+	return postgres.Open(uri), nil
+}
+
+func getSQLiteDSN(uri string) string {
+	if index := strings.Index(uri, enums.SchemeSeparator); index >= 0 && !strings.HasPrefix(uri,
+		enums.SQLiteFilePrefix) {
+		return uri[index+len(enums.SchemeSeparator):]
+	}
+
+	return uri
+}
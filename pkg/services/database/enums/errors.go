@@ -21,3 +21,32 @@ var ErrorNotFoundRecords = errors.New("{ERROR_DATABASE} database not found recor
 var ErrorConnectingToDB = errors.New("{ERROR_DATABASE} error connecting to db, use this format string for " +
 	"connection in " + EnvRelationalURI + ": 'host=localhost user=username password=user_password dbname=db_name " +
 	"port=5432 sslmode=disable TimeZone=Asia/Shanghai'")
+
+var ErrorAuditLogTableNotFound = errors.New("{ERROR_DATABASE} audit log table not found, apply the devkit " +
+	"migrations before connecting with the audit log enabled")
+
+var ErrorUnsupportedDialect = errors.New("{ERROR_DATABASE} unsupported database uri scheme, use one of " +
+	"postgres://, postgresql://, sqlite:// or file:")
+
+var (
+	ErrorInvalidQueryField     = errors.New("{ERROR_DATABASE} invalid query field or table name")
+	ErrorInvalidQueryOperator  = errors.New("{ERROR_DATABASE} invalid query operator")
+	ErrorInvalidQueryValue     = errors.New("{ERROR_DATABASE} invalid query value for the operator used on field")
+	ErrorInvalidQueryDirection = errors.New("{ERROR_DATABASE} invalid query order direction")
+	ErrorInvalidQueryJoin      = errors.New("{ERROR_DATABASE} invalid query join kind")
+	ErrorInvalidQuerySeek      = errors.New("{ERROR_DATABASE} query seek values should match the order by fields")
+	ErrorInvalidCursorEntity   = errors.New("{ERROR_DATABASE} cursor pagination requires a pointer to slice")
+)
+
+var (
+	ErrorBulkRequiresSlice     = errors.New("{ERROR_DATABASE} bulk operations requires a pointer to slice")
+	ErrorCopyInsideTransaction = errors.New("{ERROR_DATABASE} copy from is not available inside transactions")
+	ErrorCopyNotSupported      = errors.New("{ERROR_DATABASE} copy from is only supported by postgres connections")
+)
+
+var (
+	ErrorDatabaseUnavailable = errors.New("{ERROR_DATABASE} database is not available")
+	ErrorPoolSaturated       = errors.New("{ERROR_DATABASE} connection pool is almost saturated")
+	ErrorReplicationLagging  = errors.New("{ERROR_DATABASE} read replica is lagging behind the primary")
+	ErrorPendingMigrations   = errors.New("{ERROR_DATABASE} there are pending migrations to be applied")
+)
@@ -15,8 +15,12 @@
 package enums
 
 const (
-	MessageFailedToConnectToDatabase        = "{ERROR_DATABASE} failed to connect with postgres database"
+	MessageFailedToConnectToDatabase        = "{ERROR_DATABASE} failed to connect with database"
 	MessageFailedToVerifyIsAvailable        = "{ERROR_DATABASE} failed to get database while checking if is available"
 	MessageWarningDefaultDatabaseConnection = "{WARN} your user or password for connection with database " +
 		"is default content, please change for you best security"
+	MessageFailedToSetPoolConfig         = "{ERROR_DATABASE} failed to get database while setting the pool config"
+	MessageFailedToMeasureReplicationLag = "{ERROR_DATABASE} failed to measure the replication lag of read replica"
+	MessageRetryingTransaction           = "{WARN_DATABASE} transaction aborted by serialization failure or deadlock, " +
+		"retrying %d of %d"
 )
@@ -17,6 +17,88 @@ package enums
 const (
 	EnvRelationalURI     = "HORUSEC_DATABASE_SQL_URI"
 	EnvRelationalLogMode = "HORUSEC_DATABASE_SQL_LOG_MODE"
+	EnvAuditLog          = "HORUSEC_DATABASE_SQL_AUDIT_LOG"
+
+	EnvSlowQueryThreshold       = "HORUSEC_DATABASE_SQL_SLOW_QUERY_THRESHOLD_MS"
+	DefaultSlowQueryThresholdMs = 200
+
+	EnvTransactionMaxRetries   = "HORUSEC_DATABASE_SQL_TRANSACTION_MAX_RETRIES"
+	EnvTransactionRetryBackoff = "HORUSEC_DATABASE_SQL_TRANSACTION_RETRY_BACKOFF_MS"
+
+	EnvRelationalReadURIs = "HORUSEC_DATABASE_SQL_READ_URIS"
+	EnvReadRoutingPolicy  = "HORUSEC_DATABASE_SQL_READ_ROUTING_POLICY"
+	EnvMaxOpenConns       = "HORUSEC_DATABASE_SQL_MAX_OPEN_CONNS"
+	EnvMaxIdleConns       = "HORUSEC_DATABASE_SQL_MAX_IDLE_CONNS"
+	EnvConnMaxLifetime    = "HORUSEC_DATABASE_SQL_CONN_MAX_LIFETIME_SECONDS"
+	EnvConnMaxIdleTime    = "HORUSEC_DATABASE_SQL_CONN_MAX_IDLE_TIME_SECONDS"
 
 	DefaultUsernameAndPassword = "root:root"
+	DefaultPageSize            = 10
+	DefaultBatchSize           = 500
+
+	DefaultTransactionMaxRetries     = 3
+	DefaultTransactionRetryBackoffMs = 50
+
+	DefaultMaxOpenConns           = 0
+	DefaultMaxIdleConns           = 2
+	DefaultConnMaxLifetimeSeconds = 0
+	DefaultConnMaxIdleTimeSeconds = 0
+	DefaultReplicaLagRefreshSecs  = 5
+
+	ReadRoutingRoundRobin = "round-robin"
+	ReadRoutingLeastLag   = "least-lag"
+
+	PoolNameWrite = "write"
+	PoolNameRead  = "read_%d"
+
+	HealthCheckPrefix     = "database_"
+	HealthCheckDatabase   = "database"
+	HealthCheckMigrations = "database_migrations"
+
+	HealthDetailOpenConnections    = "openConnections"
+	HealthDetailInUse              = "inUse"
+	HealthDetailIdle               = "idle"
+	HealthDetailMaxOpenConnections = "maxOpenConnections"
+	HealthDetailWaitCount          = "waitCount"
+	HealthDetailPoolSaturation     = "poolSaturation"
+	HealthDetailReplicationLag     = "replicationLagSeconds"
+	HealthDetailPendingMigrations  = "pendingMigrations"
+
+	HealthMaxPoolSaturation        = 0.9
+	HealthMaxReplicationLagSeconds = 30
+
+	QueryReplicationLag = "SELECT COALESCE(EXTRACT(EPOCH FROM (now() - pg_last_xact_replay_timestamp())), 0)"
+
+	DialectPostgres = "postgres"
+	DialectSQLite   = "sqlite"
+
+	SchemeSeparator  = "://"
+	SchemePostgres   = "postgres"
+	SchemePostgresql = "postgresql"
+	SchemeSQLite     = "sqlite"
+	SchemeSQLite3    = "sqlite3"
+	SQLiteFilePrefix = "file:"
+
+	SQLStateSerializationFailure = "40001"
+	SQLStateDeadlockDetected     = "40P01"
+	SQLStateUniqueViolation      = "23505"
+	SQLStateForeignKeyViolation  = "23503"
+	SQLStateTooManyConnections   = "53300"
+	SQLStateAdminShutdown        = "57P01"
+	SQLStateCrashShutdown        = "57P02"
+	SQLStateCannotConnectNow     = "57P03"
+
+	SQLStateClassConnectionException = "08"
+
+	// sqlite result codes, available at https://www.sqlite.org/rescode.html, where the primary code is the least
+	// significant byte of the extended code.
+	SQLiteCodeMask                 = 0xff
+	SQLiteCodeBusy                 = 5
+	SQLiteCodeLocked               = 6
+	SQLiteCodeIOError              = 10
+	SQLiteCodeCantOpen             = 14
+	SQLiteCodeConstraint           = 19
+	SQLiteCodeConstraintForeignKey = 787
+	SQLiteCodeConstraintPrimaryKey = 1555
+	SQLiteCodeConstraintUnique     = 2067
 )
@@ -0,0 +1,177 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	healthEntities "github.com/Fotkurz/horusec-devkit/pkg/entities/health"
+	healthEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/health"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/migrations"
+	migrationsEnums "github.com/Fotkurz/horusec-devkit/pkg/services/database/migrations/enums"
+)
+
+// HealthChecker reports the health of the database connections, with the latency of each connection, the
+// saturation of its pool, the replication lag of the read replicas and the pending migrations.
+type HealthChecker struct {
+	connection *Connection
+	migrator   migrations.IMigrator
+}
+
+// NewHealthChecker creates a new database health checker, where the migrator could be nil when the service is not
+// the one responsible for the migrations. Usage example:
+//
+//	healthHandler := health.NewHandler(database.NewHealthChecker(connection, migrator))
+func NewHealthChecker(connection *Connection, migrator migrations.IMigrator) healthEntities.IChecker {
+	return &HealthChecker{
+		connection: connection,
+		migrator:   migrator,
+	}
+}
+
+func (h *HealthChecker) CheckHealth(ctx context.Context) *healthEntities.Report {
+	report := healthEntities.NewReport()
+	if h.connection.database == nil {
+		return report.AddChecks(h.checkAvailability())
+	}
+
+	report.AddChecks(h.checkConnection(ctx, enums.PoolNameWrite, h.connection.database.connectionWrite))
+	report.AddChecks(h.checkReadConnections(ctx)...)
+
+	if h.migrator != nil {
+		report.AddChecks(h.checkMigrations())
+	}
+
+	return report
+}
+
+// checkAvailability is used by connections not created by NewDatabaseReadAndWrite, like the ones using mocks.
+func (h *HealthChecker) checkAvailability() *healthEntities.Check {
+	check := healthEntities.NewCheck(enums.HealthCheckDatabase, healthEnums.Up)
+	if !h.connection.Read.IsAvailable() || !h.connection.Write.IsAvailable() {
+		check.SetError(healthEnums.Down, enums.ErrorDatabaseUnavailable)
+	}
+
+	return check
+}
+
+func (h *HealthChecker) checkReadConnections(ctx context.Context) []*healthEntities.Check {
+	connections := h.connection.database.getReadConnections()
+	lags := h.getReplicationLags()
+	checks := make([]*healthEntities.Check, 0, len(connections))
+
+	for index, connection := range connections {
+		check := h.checkConnection(ctx, fmt.Sprintf(enums.PoolNameRead, index), connection)
+		if index < len(lags) {
+			h.setReplicationLag(check, lags[index])
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// getReplicationLags returns the lags only for postgres, the unique dialect with read replicas support.
+func (h *HealthChecker) getReplicationLags() []time.Duration {
+	if h.connection.database.replicas == nil ||
+		h.connection.database.connectionWrite.Dialector.Name() != enums.DialectPostgres {
+		return nil
+	}
+
+	return h.connection.database.replicas.getReplicationLags()
+}
+
+func (h *HealthChecker) checkConnection(ctx context.Context, name string, connection *gorm.DB) *healthEntities.Check {
+	check := healthEntities.NewCheck(enums.HealthCheckPrefix+name, healthEnums.Up)
+	if connection == nil {
+		return check.SetError(healthEnums.Down, enums.ErrorDatabaseUnavailable)
+	}
+
+	sqlDB, err := connection.DB()
+	if err != nil {
+		return check.SetError(healthEnums.Down, err)
+	}
+
+	start := time.Now()
+	if err = sqlDB.PingContext(ctx); err != nil {
+		return check.SetError(healthEnums.Down, err)
+	}
+
+	check.SetLatency(time.Since(start))
+
+	return h.setPoolStats(check, sqlDB.Stats())
+}
+
+func (h *HealthChecker) setPoolStats(check *healthEntities.Check, stats sql.DBStats) *healthEntities.Check {
+	saturation := 0.0
+	if stats.MaxOpenConnections > 0 {
+		saturation = float64(stats.InUse) / float64(stats.MaxOpenConnections)
+	}
+
+	check.SetDetail(enums.HealthDetailOpenConnections, stats.OpenConnections).
+		SetDetail(enums.HealthDetailInUse, stats.InUse).
+		SetDetail(enums.HealthDetailIdle, stats.Idle).
+		SetDetail(enums.HealthDetailMaxOpenConnections, stats.MaxOpenConnections).
+		SetDetail(enums.HealthDetailWaitCount, stats.WaitCount).
+		SetDetail(enums.HealthDetailPoolSaturation, saturation)
+
+	if saturation >= enums.HealthMaxPoolSaturation {
+		check.SetError(healthEnums.Degraded, enums.ErrorPoolSaturated)
+	}
+
+	return check
+}
+
+func (h *HealthChecker) setReplicationLag(check *healthEntities.Check, lag time.Duration) {
+	check.SetDetail(enums.HealthDetailReplicationLag, lag.Seconds())
+
+	if lag > enums.HealthMaxReplicationLagSeconds*time.Second {
+		check.SetError(healthEnums.Degraded, enums.ErrorReplicationLagging)
+	}
+}
+
+// checkMigrations only reads the migrations table, being unknown when the migrator was never run on the database.
+func (h *HealthChecker) checkMigrations() *healthEntities.Check {
+	check := healthEntities.NewCheck(enums.HealthCheckMigrations, healthEnums.Up)
+
+	pending, err := h.migrator.CheckPending()
+	if errors.Is(err, migrationsEnums.ErrorMigrationsNotInitialized) {
+		return check.SetError(healthEnums.Unknown, err)
+	}
+
+	if err != nil {
+		return check.SetError(healthEnums.Degraded, err)
+	}
+
+	versions := make([]uint64, 0, len(pending))
+	for index := range pending {
+		versions = append(versions, pending[index].Version)
+	}
+
+	check.SetDetail(enums.HealthDetailPendingMigrations, versions)
+	if len(versions) > 0 {
+		check.SetError(healthEnums.Degraded, enums.ErrorPendingMigrations)
+	}
+
+	return check
+}
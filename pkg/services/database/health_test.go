@@ -0,0 +1,172 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	healthEntities "github.com/Fotkurz/horusec-devkit/pkg/entities/health"
+	healthEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/health"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/config"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/migrations"
+	migrationsEnums "github.com/Fotkurz/horusec-devkit/pkg/services/database/migrations/enums"
+)
+
+func getSQLiteHealthConnection(t *testing.T, name string) *Connection {
+	databaseConfig := config.NewDatabaseConfig()
+	databaseConfig.SetURI("file:" + name + "?mode=memory&cache=shared")
+	databaseConfig.SetReadURIs(nil)
+
+	connection, err := NewDatabaseReadAndWrite(databaseConfig)
+	assert.NoError(t, err)
+
+	return connection
+}
+
+func TestNewHealthChecker(t *testing.T) {
+	t.Run("should success create a new health checker", func(t *testing.T) {
+		assert.NotNil(t, NewHealthChecker(&Connection{}, nil))
+	})
+}
+
+func TestCheckHealth(t *testing.T) {
+	t.Run("should return up when the connections are available", func(t *testing.T) {
+		checker := NewHealthChecker(getSQLiteHealthConnection(t, "health_up"), nil)
+
+		report := checker.CheckHealth(context.Background())
+
+		assert.Equal(t, healthEnums.Up, report.Status)
+		assert.Len(t, report.Checks, 2)
+		assert.Equal(t, enums.HealthCheckPrefix+enums.PoolNameWrite, report.Checks[0].Name)
+		assert.Contains(t, report.Checks[0].Details, enums.HealthDetailPoolSaturation)
+	})
+
+	t.Run("should return degraded when there are pending migrations", func(t *testing.T) {
+		connection := getSQLiteHealthConnection(t, "health_migrations")
+		migrator := migrations.NewMigrator(connection.database.connectionWrite, fstest.MapFS{
+			"000001_create_analysis.up.sql":   {Data: []byte("CREATE TABLE analysis (id TEXT PRIMARY KEY);")},
+			"000001_create_analysis.down.sql": {Data: []byte("DROP TABLE analysis;")},
+		})
+
+		_, err := migrator.Applied()
+		assert.NoError(t, err)
+
+		report := NewHealthChecker(connection, migrator).CheckHealth(context.Background())
+
+		assert.Equal(t, healthEnums.Degraded, report.Status)
+		assert.Equal(t, enums.HealthCheckMigrations, report.Checks[2].Name)
+		assert.Equal(t, []uint64{1}, report.Checks[2].Details[enums.HealthDetailPendingMigrations])
+		assert.Equal(t, enums.ErrorPendingMigrations.Error(), report.Checks[2].Error)
+	})
+
+	t.Run("should return unknown without creating the migrations table when it is missing", func(t *testing.T) {
+		connection := getSQLiteHealthConnection(t, "health_migrations_unknown")
+		migrator := migrations.NewMigrator(connection.database.connectionWrite, fstest.MapFS{
+			"000001_create_analysis.up.sql": {Data: []byte("CREATE TABLE analysis (id TEXT PRIMARY KEY);")},
+		})
+
+		report := NewHealthChecker(connection, migrator).CheckHealth(context.Background())
+
+		assert.Equal(t, healthEnums.Unknown, report.Status)
+		assert.Equal(t, healthEnums.Unknown, report.Checks[2].Status)
+		assert.Equal(t, migrationsEnums.ErrorMigrationsNotInitialized.Error(), report.Checks[2].Error)
+		assert.False(t, connection.database.connectionWrite.Migrator().HasTable(migrationsEnums.MigrationsTable))
+	})
+
+	t.Run("should return down when failed to ping the database", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		assert.NoError(t, err)
+
+		mock.ExpectPing().WillReturnError(errors.New("test"))
+		mock.ExpectPing().WillReturnError(errors.New("test"))
+
+		connection := &Connection{database: &database{connectionWrite: getMockedConnection(db),
+			connectionRead: getMockedConnection(db)}}
+
+		report := NewHealthChecker(connection, nil).CheckHealth(context.Background())
+
+		assert.Equal(t, healthEnums.Down, report.Status)
+		assert.False(t, report.IsAvailable())
+	})
+
+	t.Run("should return down when the mocked connections are not available", func(t *testing.T) {
+		databaseMock := &Mock{}
+		databaseMock.On("IsAvailable").Return(false)
+
+		report := NewHealthChecker(&Connection{Read: databaseMock, Write: databaseMock}, nil).
+			CheckHealth(context.Background())
+
+		assert.Equal(t, healthEnums.Down, report.Status)
+		assert.Equal(t, enums.HealthCheckDatabase, report.Checks[0].Name)
+	})
+
+	t.Run("should return up when the mocked connections are available", func(t *testing.T) {
+		databaseMock := &Mock{}
+		databaseMock.On("IsAvailable").Return(true)
+
+		report := NewHealthChecker(&Connection{Read: databaseMock, Write: databaseMock}, nil).
+			CheckHealth(context.Background())
+
+		assert.Equal(t, healthEnums.Up, report.Status)
+	})
+}
+
+func TestSetPoolStats(t *testing.T) {
+	t.Run("should return degraded when the pool is saturated", func(t *testing.T) {
+		check := healthEntities.NewCheck(enums.HealthCheckDatabase, healthEnums.Up)
+
+		(&HealthChecker{}).setPoolStats(check, sql.DBStats{MaxOpenConnections: 10, InUse: 10})
+
+		assert.Equal(t, healthEnums.Degraded, check.Status)
+		assert.Equal(t, enums.ErrorPoolSaturated.Error(), check.Error)
+		assert.Equal(t, 1.0, check.Details[enums.HealthDetailPoolSaturation])
+	})
+
+	t.Run("should return up when the pool has no limit", func(t *testing.T) {
+		check := healthEntities.NewCheck(enums.HealthCheckDatabase, healthEnums.Up)
+
+		(&HealthChecker{}).setPoolStats(check, sql.DBStats{InUse: 10})
+
+		assert.Equal(t, healthEnums.Up, check.Status)
+	})
+}
+
+func TestSetReplicationLag(t *testing.T) {
+	t.Run("should return degraded when the replication lag is above the limit", func(t *testing.T) {
+		check := healthEntities.NewCheck(enums.HealthCheckDatabase, healthEnums.Up)
+
+		(&HealthChecker{}).setReplicationLag(check, time.Minute)
+
+		assert.Equal(t, healthEnums.Degraded, check.Status)
+		assert.Equal(t, time.Minute.Seconds(), check.Details[enums.HealthDetailReplicationLag])
+	})
+
+	t.Run("should return up when the replication lag is below the limit", func(t *testing.T) {
+		check := healthEntities.NewCheck(enums.HealthCheckDatabase, healthEnums.Up)
+
+		(&HealthChecker{}).setReplicationLag(check, time.Second)
+
+		assert.Equal(t, healthEnums.Up, check.Status)
+	})
+}
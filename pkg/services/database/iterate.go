@@ -0,0 +1,139 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/query"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
+)
+
+// errStopIteration is returned by the callback of All when the consumer of the iterator stops it.
+var errStopIteration = errors.New("stop iteration")
+
+// All returns an iterator over the records matching the query, streamed using Iterate, where each yielded entity is
+// a new copy that could be kept by the consumer. It is shaped as an iter.Seq2, so with go 1.23 or newer it can be
+// ranged over: for vulnerability, err := range database.All[vulnerability.Vulnerability](read, spec, table).
+// When the iteration fails the error is yielded with a nil entity as the last value.
+func All[T any](read IDatabaseRead, spec *query.Query, table string) func(yield func(*T, error) bool) {
+	return func(yield func(*T, error) bool) {
+		entity := new(T)
+
+		result := read.Iterate(entity, spec, table, func() error {
+			item := *entity
+			if !yield(&item, nil) {
+				return errStopIteration
+			}
+
+			return nil
+		})
+
+		if err := result.GetError(); err != nil && !errors.Is(err, errStopIteration) {
+			yield(nil, err)
+		}
+	}
+}
+
+// Iterate streams the records matching the query one by one into the entity pointer, calling the callback after
+// each of them is scanned, so large result sets are read in constant memory. The iteration stops at the first
+// callback error or when the context of the connection, see WithContext, is canceled. A nil query reads the whole
+// table. The response rows affected is the number of records iterated and the response has no data.
+// Usage example:
+//
+//	vulnerability := &vulnerability.Vulnerability{}
+//	result := connection.WithContext(ctx).Read.Iterate(vulnerability, spec, table, func() error {
+//	  return encoder.Encode(vulnerability)
+//	})
+func (d *database) Iterate(entityPointer interface{}, spec *query.Query, table string,
+	callback func() error) response.IResponse {
+	statement, err := d.applyQuery(spec, table)
+	if err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	rows, err := statement.Model(entityPointer).Rows()
+	if err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	count, err := d.iterateRows(statement, rows, entityPointer, callback)
+
+	return response.NewResponse(count, err, nil)
+}
+
+func (d *database) iterateRows(statement *gorm.DB, rows *sql.Rows, entityPointer interface{},
+	callback func() error) (count int64, err error) {
+	entity := reflect.Indirect(reflect.ValueOf(entityPointer))
+
+	for rows.Next() {
+		if err = d.getContextError(statement); err != nil {
+			return count, err
+		}
+
+		entity.Set(reflect.Zero(entity.Type()))
+		if err = statement.ScanRows(rows, entityPointer); err != nil {
+			return count, err
+		}
+
+		if err = callback(); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, rows.Err()
+}
+
+// FindInBatches reads the records matching the query in batches of the size into the entities pointer, which must
+// be a pointer to slice, calling the callback with the batch number, starting at 1, after each batch is read. The
+// records are ordered by the primary key of the entity, which is also used to get the next batch, so the query
+// should not have orders. The iteration stops at the first callback error or when the context of the connection is
+// canceled. When size is zero enums.DefaultBatchSize is used. The response rows affected is the number of records
+// read and the response has no data.
+func (d *database) FindInBatches(entitiesPointer interface{}, spec *query.Query, table string, size int,
+	callback func(batch int) error) response.IResponse {
+	statement, err := d.applyQuery(spec, table)
+	if err != nil {
+		return response.NewResponse(0, err, nil)
+	}
+
+	result := statement.FindInBatches(entitiesPointer, d.getBatchSize(size), func(tx *gorm.DB, batch int) error {
+		if err := d.getContextError(tx); err != nil {
+			return err
+		}
+
+		return callback(batch)
+	})
+
+	return response.NewResponse(result.RowsAffected, result.Error, nil)
+}
+
+func (d *database) getContextError(statement *gorm.DB) error {
+	ctx := statement.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return ctx.Err()
+}
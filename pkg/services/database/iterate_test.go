@@ -0,0 +1,206 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/config"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/query"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
+)
+
+type testIterateEntity struct {
+	ID   int    `gorm:"Column:id;primaryKey"`
+	Name string `gorm:"Column:name"`
+}
+
+func getIterateConnection(t *testing.T, name string, records int) *Connection {
+	databaseConfig := config.NewDatabaseConfig()
+	databaseConfig.SetURI("file:" + name + "?mode=memory&cache=shared")
+	databaseConfig.SetReadURIs(nil)
+
+	connection, err := NewDatabaseReadAndWrite(databaseConfig)
+	assert.NoError(t, err)
+	assert.NoError(t, connection.Write.Exec("CREATE TABLE test_iterate (id INTEGER PRIMARY KEY, name TEXT)"))
+
+	for id := 1; id <= records; id++ {
+		assert.NoError(t, connection.Write.Create(&testIterateEntity{ID: id, Name: fmt.Sprint("test", id)},
+			"test_iterate").GetError())
+	}
+
+	return connection
+}
+
+func TestIterate(t *testing.T) {
+	t.Run("should stream the records matching the query", func(t *testing.T) {
+		connection := getIterateConnection(t, "iterate", 5)
+
+		var names []string
+		entity := &testIterateEntity{}
+
+		result := connection.Read.Iterate(entity, query.New().Where("id", query.GreaterThan, 2).
+			OrderBy("id", query.Ascending), "test_iterate", func() error {
+			names = append(names, entity.Name)
+
+			return nil
+		})
+
+		assert.NoError(t, result.GetError())
+		assert.Equal(t, 3, result.GetRowsAffected())
+		assert.Equal(t, []string{"test3", "test4", "test5"}, names)
+	})
+
+	t.Run("should stop when the callback returns error", func(t *testing.T) {
+		connection := getIterateConnection(t, "iterate_callback_error", 3)
+
+		result := connection.Read.Iterate(&testIterateEntity{}, nil, "test_iterate", func() error {
+			return errors.New("test")
+		})
+
+		assert.Equal(t, errors.New("test"), result.GetError())
+		assert.Equal(t, 0, result.GetRowsAffected())
+	})
+
+	t.Run("should stop when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		connection := getIterateConnection(t, "iterate_canceled", 3).WithContext(ctx)
+
+		result := connection.Read.Iterate(&testIterateEntity{}, nil, "test_iterate", func() error {
+			cancel()
+
+			return nil
+		})
+
+		assert.ErrorIs(t, result.GetError(), context.Canceled)
+		assert.Equal(t, 1, result.GetRowsAffected())
+	})
+
+	t.Run("should return error when invalid query", func(t *testing.T) {
+		connection := getIterateConnection(t, "iterate_invalid_query", 0)
+
+		result := connection.Read.Iterate(&testIterateEntity{}, query.New().Where("id;", query.Equal, 1),
+			"test_iterate", func() error { return nil })
+
+		assert.Error(t, result.GetError())
+	})
+}
+
+func TestFindInBatches(t *testing.T) {
+	t.Run("should read the records in batches", func(t *testing.T) {
+		connection := getIterateConnection(t, "find_in_batches", 5)
+
+		var batches [][]testIterateEntity
+		var entities []testIterateEntity
+
+		result := connection.Read.FindInBatches(&entities, nil, "test_iterate", 2, func(_ int) error {
+			batches = append(batches, entities)
+
+			return nil
+		})
+
+		assert.NoError(t, result.GetError())
+		assert.Equal(t, 5, result.GetRowsAffected())
+		assert.Len(t, batches, 3)
+		assert.Equal(t, 5, batches[2][0].ID)
+	})
+
+	t.Run("should stop when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		connection := getIterateConnection(t, "find_in_batches_canceled", 5).WithContext(ctx)
+
+		batches := 0
+		var entities []testIterateEntity
+
+		result := connection.Read.FindInBatches(&entities, nil, "test_iterate", 2, func(batch int) error {
+			batches = batch
+			cancel()
+
+			return nil
+		})
+
+		assert.ErrorIs(t, result.GetError(), context.Canceled)
+		assert.Equal(t, 1, batches)
+	})
+
+	t.Run("should use the default batch size when size is zero", func(t *testing.T) {
+		connection := getIterateConnection(t, "find_in_batches_default_size", 12)
+
+		batches := 0
+		var entities []testIterateEntity
+
+		result := connection.Read.FindInBatches(&entities, nil, "test_iterate", 0, func(batch int) error {
+			batches = batch
+
+			return nil
+		})
+
+		assert.NoError(t, result.GetError())
+		assert.Equal(t, 1, batches)
+		assert.Len(t, entities, 12)
+	})
+}
+
+func TestAll(t *testing.T) {
+	t.Run("should yield a copy of each record", func(t *testing.T) {
+		connection := getIterateConnection(t, "all", 3)
+
+		var entities []*testIterateEntity
+		All[testIterateEntity](connection.Read, nil, "test_iterate")(func(entity *testIterateEntity, err error) bool {
+			assert.NoError(t, err)
+			entities = append(entities, entity)
+
+			return true
+		})
+
+		assert.Len(t, entities, 3)
+		assert.Equal(t, "test1", entities[0].Name)
+		assert.Equal(t, "test3", entities[2].Name)
+	})
+
+	t.Run("should stop when the consumer stops", func(t *testing.T) {
+		connection := getIterateConnection(t, "all_stop", 3)
+
+		count := 0
+		All[testIterateEntity](connection.Read, nil, "test_iterate")(func(_ *testIterateEntity, err error) bool {
+			assert.NoError(t, err)
+			count++
+
+			return false
+		})
+
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("should yield the error of the iteration", func(t *testing.T) {
+		databaseMock := &Mock{}
+		databaseMock.On("Iterate").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		var iterationErr error
+		All[testIterateEntity](databaseMock, nil, "test_iterate")(func(entity *testIterateEntity, err error) bool {
+			assert.Nil(t, entity)
+			iterationErr = err
+
+			return true
+		})
+
+		assert.Equal(t, errors.New("test"), iterationErr)
+	})
+}
@@ -0,0 +1 @@
+ALTER TABLE vulnerabilities DROP COLUMN cvss_vector;
@@ -0,0 +1 @@
+ALTER TABLE vulnerabilities ADD COLUMN cvss_vector VARCHAR(255);
@@ -0,0 +1 @@
+DROP TABLE horusec_audit_logs;
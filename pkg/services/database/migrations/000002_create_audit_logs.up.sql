@@ -0,0 +1,12 @@
+CREATE TABLE horusec_audit_logs (
+    audit_log_id UUID PRIMARY KEY,
+    table_name VARCHAR(255) NOT NULL,
+    operation VARCHAR(255) NOT NULL,
+    filter TEXT,
+    changes TEXT,
+    account_id UUID,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_horusec_audit_logs_table_name ON horusec_audit_logs (table_name);
+CREATE INDEX idx_horusec_audit_logs_account_id ON horusec_audit_logs (account_id);
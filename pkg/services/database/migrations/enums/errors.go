@@ -0,0 +1,30 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+import "errors"
+
+var (
+	ErrorMigrationLocked           = errors.New("{ERROR_MIGRATIONS} migrations are locked by another process")
+	ErrorMigrationChecksumMismatch = errors.New("{ERROR_MIGRATIONS} applied migration content was changed")
+	ErrorMigrationNotFound         = errors.New("{ERROR_MIGRATIONS} migration file not found")
+	ErrorMigrationWithoutDown      = errors.New("{ERROR_MIGRATIONS} migration has no down file")
+	ErrorDuplicatedMigration       = errors.New("{ERROR_MIGRATIONS} duplicated migration version")
+	ErrorLegacyMigrationDirty      = errors.New("{ERROR_MIGRATIONS} golang-migrate schema_migrations is dirty, " +
+		"fix the failed migration and its version before using the migrator")
+	ErrorInvalidMigrationVersion  = errors.New("{ERROR_MIGRATIONS} migration version must not be negative")
+	ErrorMigrationsNotInitialized = errors.New("{ERROR_MIGRATIONS} migrations table not found, the migrator " +
+		"was never run on the database")
+)
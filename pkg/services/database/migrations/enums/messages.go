@@ -0,0 +1,24 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+const (
+	MessageApplyingMigration   = "{MIGRATIONS} applying migration %d_%s"
+	MessageRevertingMigration  = "{MIGRATIONS} reverting migration %d_%s"
+	MessageDryRunMigration     = "{MIGRATIONS} dry run, migration %d_%s would be %s"
+	MessageFailedToUnlock      = "{ERROR_MIGRATIONS} failed to release migrations lock"
+	MessageNoMigrationsToApply = "{MIGRATIONS} database is up to date, no migrations to apply"
+	MessageAdoptingLegacy      = "{MIGRATIONS} adopting %d migrations applied by golang-migrate until version %d"
+)
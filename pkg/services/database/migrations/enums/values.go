@@ -0,0 +1,38 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+const (
+	MigrationsTable     = "horusec_schema_migrations"
+	MigrationsLockTable = "horusec_schema_migrations_lock"
+	MigrationsLockID    = 1
+
+	// LegacyMigrationsTable is the table of golang-migrate, used by the migration scripts, which keeps only the
+	// version of the last applied migration and if it failed.
+	LegacyMigrationsTable = "schema_migrations"
+
+	EnvMigrationsPath   = "HORUSEC_DATABASE_SQL_MIGRATIONS_PATH"
+	EnvMigrationsDryRun = "HORUSEC_DATABASE_SQL_MIGRATIONS_DRY_RUN"
+
+	DefaultMigrationsPath = "./pkg/services/database/migrations"
+
+	DirectionUp   = "up"
+	DirectionDown = "down"
+
+	CreateMigrationsTable = "CREATE TABLE IF NOT EXISTS " + MigrationsTable + " (version BIGINT PRIMARY KEY, " +
+		"name VARCHAR(255) NOT NULL, checksum VARCHAR(64) NOT NULL, applied_at TIMESTAMP NOT NULL)"
+	CreateMigrationsLockTable = "CREATE TABLE IF NOT EXISTS " + MigrationsLockTable + " (id INTEGER PRIMARY KEY, " +
+		"locked_at TIMESTAMP NOT NULL)"
+)
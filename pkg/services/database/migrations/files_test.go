@@ -0,0 +1,43 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestFiles(t *testing.T) {
+	t.Run("should success apply and revert the devkit migrations using sqlite", func(t *testing.T) {
+		connection, err := gorm.Open(sqlite.Open("file:files?mode=memory&cache=shared"), &gorm.Config{})
+		assert.NoError(t, err)
+		assert.NoError(t, connection.Exec("CREATE TABLE vulnerabilities (vulnerability_id TEXT PRIMARY KEY)").Error)
+
+		migrations, err := LoadMigrations(Files)
+		assert.NoError(t, err)
+
+		applied, err := NewMigrator(connection, Files).Up()
+		assert.NoError(t, err)
+		assert.Len(t, applied, len(migrations))
+		assert.True(t, connection.Migrator().HasColumn("vulnerabilities", "cvss_vector"))
+
+		_, err = NewMigrator(connection, Files).To(0)
+		assert.NoError(t, err)
+		assert.False(t, connection.Migrator().HasColumn("vulnerabilities", "cvss_vector"))
+	})
+}
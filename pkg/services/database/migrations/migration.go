@@ -0,0 +1,141 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrations
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/migrations/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/crypto"
+)
+
+// fileNameRegex follows the golang-migrate file name format used by the migration scripts, like
+// "000001_create_analysis_table.up.sql" and "000001_create_analysis_table.down.sql".
+var fileNameRegex = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration represents a versioned change of the database schema, where the checksum is calculated from the up
+// content and is used to identify applied migrations that were changed afterwards.
+type Migration struct {
+	Version  uint64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// AppliedMigration is the record saved into the migrations table after applying a migration.
+type AppliedMigration struct {
+	Version   uint64    `json:"version" gorm:"Column:version"`
+	Name      string    `json:"name" gorm:"Column:name"`
+	Checksum  string    `json:"checksum" gorm:"Column:checksum"`
+	AppliedAt time.Time `json:"appliedAt" gorm:"Column:applied_at"`
+}
+
+// legacyMigration is the single record of the golang-migrate table, with the version of the last applied migration
+// and if it failed in the middle, being dirty.
+type legacyMigration struct {
+	Version uint64 `gorm:"Column:version"`
+	Dirty   bool   `gorm:"Column:dirty"`
+}
+
+type migrationLock struct {
+	ID       int       `gorm:"Column:id;primaryKey;autoIncrement:false"`
+	LockedAt time.Time `gorm:"Column:locked_at"`
+}
+
+// LoadMigrations reads all migration files from the root of the source, usually an embed.FS, returning them sorted by
+// version. Files that don't follow the migration file name format are ignored.
+func LoadMigrations(source fs.FS) ([]Migration, error) {
+	files, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := map[uint64]*Migration{}
+
+	for _, file := range files {
+		if err := loadMigrationFile(source, file, migrations); err != nil {
+			return nil, err
+		}
+	}
+
+	return sortMigrations(migrations)
+}
+
+func loadMigrationFile(source fs.FS, file fs.DirEntry, migrations map[uint64]*Migration) error {
+	matches := fileNameRegex.FindStringSubmatch(file.Name())
+	if file.IsDir() || matches == nil {
+		return nil
+	}
+
+	content, err := fs.ReadFile(source, file.Name())
+	if err != nil {
+		return err
+	}
+
+	version, err := strconv.ParseUint(matches[1], 10, 64)
+	if err != nil {
+		return err
+	}
+
+	return setMigrationContent(migrations, version, matches[2], matches[3], string(content))
+}
+
+func setMigrationContent(migrations map[uint64]*Migration, version uint64, name, direction, content string) error {
+	migration, ok := migrations[version]
+	if !ok {
+		migration = &Migration{Version: version, Name: name}
+		migrations[version] = migration
+	}
+
+	if migration.Name != name || direction == enums.DirectionUp && migration.Up != "" ||
+		direction == enums.DirectionDown && migration.Down != "" {
+		return fmt.Errorf("%w: %d", enums.ErrorDuplicatedMigration, version)
+	}
+
+	if direction == enums.DirectionUp {
+		migration.Up = content
+		migration.Checksum = crypto.GenerateSHA256(content)
+
+		return nil
+	}
+
+	migration.Down = content
+
+	return nil
+}
+
+func sortMigrations(migrations map[uint64]*Migration) ([]Migration, error) {
+	sorted := make([]Migration, 0, len(migrations))
+
+	for _, migration := range migrations {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("%w: %d_%s.up.sql", enums.ErrorMigrationNotFound, migration.Version, migration.Name)
+		}
+
+		sorted = append(sorted, *migration)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	return sorted, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrations
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/migrations/enums"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("should success load migrations sorted by version", func(t *testing.T) {
+		migrations, err := LoadMigrations(fstest.MapFS{
+			"000002_create_vulnerabilities.up.sql":   {Data: []byte("CREATE TABLE vulnerabilities();")},
+			"000002_create_vulnerabilities.down.sql": {Data: []byte("DROP TABLE vulnerabilities;")},
+			"000001_create_analysis.up.sql":          {Data: []byte("CREATE TABLE analysis();")},
+			"README.md":                              {Data: []byte("test")},
+			"000003_folder.up.sql/file":              {Data: []byte("test")},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, migrations, 2)
+		assert.Equal(t, uint64(1), migrations[0].Version)
+		assert.Equal(t, "create_analysis", migrations[0].Name)
+		assert.Empty(t, migrations[0].Down)
+		assert.Equal(t, uint64(2), migrations[1].Version)
+		assert.Equal(t, "CREATE TABLE vulnerabilities();", migrations[1].Up)
+		assert.Equal(t, "DROP TABLE vulnerabilities;", migrations[1].Down)
+		assert.Len(t, migrations[1].Checksum, 64)
+	})
+
+	t.Run("should return error when migration has no up file", func(t *testing.T) {
+		_, err := LoadMigrations(fstest.MapFS{
+			"000001_create_analysis.down.sql": {Data: []byte("DROP TABLE analysis;")},
+		})
+
+		assert.True(t, errors.Is(err, enums.ErrorMigrationNotFound))
+	})
+
+	t.Run("should return error when migration version is duplicated", func(t *testing.T) {
+		_, err := LoadMigrations(fstest.MapFS{
+			"000001_create_analysis.up.sql":    {Data: []byte("CREATE TABLE analysis();")},
+			"000001_create_other_table.up.sql": {Data: []byte("CREATE TABLE other();")},
+		})
+
+		assert.True(t, errors.Is(err, enums.ErrorDuplicatedMigration))
+	})
+
+	t.Run("should return error when version is too big", func(t *testing.T) {
+		_, err := LoadMigrations(fstest.MapFS{
+			"99999999999999999999999_test.up.sql": {Data: []byte("SELECT 1;")},
+		})
+
+		assert.Error(t, err)
+	})
+}
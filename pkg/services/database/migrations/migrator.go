@@ -0,0 +1,430 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrations
+
+import (
+	"fmt"
+	"io/fs"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+
+	databaseConfig "github.com/Fotkurz/horusec-devkit/pkg/services/database/config"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/dialect"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/migrations/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/logger"
+)
+
+// IMigrator applies the versioned migrations of a source into the database. Every method returns the migrations that
+// were executed, or that would be executed when dry run is enabled.
+type IMigrator interface {
+	Up() ([]Migration, error)
+	Down() ([]Migration, error)
+	To(version uint64) ([]Migration, error)
+	Pending() ([]Migration, error)
+	CheckPending() ([]Migration, error)
+	Applied() ([]AppliedMigration, error)
+	ForceUnlock() error
+	SetDryRun(dryRun bool)
+}
+
+type Migrator struct {
+	connection *gorm.DB
+	source     fs.FS
+	dryRun     bool
+}
+
+type step struct {
+	migration Migration
+	up        bool
+}
+
+// NewMigrator creates a migrator using the migration files of the source, which can be an embed.FS containing the
+// files in its root. Usage example to run the migrations during the service startup:
+//
+//	//go:embed *.sql
+//	var files embed.FS
+//
+//	_, err := migrations.NewMigrator(connection, files).Up()
+func NewMigrator(connection *gorm.DB, source fs.FS) IMigrator {
+	return &Migrator{
+		connection: connection,
+		source:     source,
+	}
+}
+
+// NewMigratorFromConfig opens a new connection using the database config to create a migrator.
+func NewMigratorFromConfig(config databaseConfig.IConfig, source fs.FS) (IMigrator, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	dialector, err := dialect.Open(config.GetURI())
+	if err != nil {
+		return nil, err
+	}
+
+	connection, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMigrator(connection, source), nil
+}
+
+// SetDryRun when enabled, the migrations are not applied and the lock is not acquired, only the tracking tables are
+// created when missing.
+func (m *Migrator) SetDryRun(dryRun bool) {
+	m.dryRun = dryRun
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up() ([]Migration, error) {
+	return m.To(math.MaxUint64)
+}
+
+// Down reverts the last applied migration.
+func (m *Migrator) Down() ([]Migration, error) {
+	return m.migrate(func(migrations []Migration, applied []AppliedMigration) ([]step, error) {
+		if len(applied) == 0 {
+			return nil, nil
+		}
+
+		return m.planDown(migrations, applied[len(applied)-1:])
+	})
+}
+
+// To reverts the applied migrations after the version and applies the pending ones until the version.
+func (m *Migrator) To(version uint64) ([]Migration, error) {
+	return m.migrate(func(migrations []Migration, applied []AppliedMigration) ([]step, error) {
+		var toRevert []AppliedMigration
+
+		for index := len(applied) - 1; index >= 0; index-- {
+			if applied[index].Version > version {
+				toRevert = append(toRevert, applied[index])
+			}
+		}
+
+		steps, err := m.planDown(migrations, toRevert)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(steps, m.planUp(migrations, applied, version)...), nil
+	})
+}
+
+// Pending returns the migrations that are not applied yet, considering the ones applied by golang-migrate when the
+// migrator has not applied any migration yet, without recording them.
+func (m *Migrator) Pending() ([]Migration, error) {
+	migrations, applied, err := m.loadState(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.getPending(migrations, applied), nil
+}
+
+// CheckPending returns the pending migrations like Pending, but only reading the database, without creating the
+// tracking tables, so it could be used by health checks. When the migrations table doesn't exist it returns
+// enums.ErrorMigrationsNotInitialized, since it's not known if the database was migrated by other tool.
+func (m *Migrator) CheckPending() ([]Migration, error) {
+	if !m.connection.Migrator().HasTable(enums.MigrationsTable) {
+		return nil, enums.ErrorMigrationsNotInitialized
+	}
+
+	migrations, err := LoadMigrations(m.source)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := m.findApplied()
+	if err != nil {
+		return nil, err
+	}
+
+	if applied, err = m.adoptLegacy(migrations, applied, false); err != nil {
+		return nil, err
+	}
+
+	return m.getPending(migrations, applied), nil
+}
+
+func (m *Migrator) Applied() ([]AppliedMigration, error) {
+	if err := m.createTables(); err != nil {
+		return nil, err
+	}
+
+	return m.findApplied()
+}
+
+func (m *Migrator) findApplied() (applied []AppliedMigration, err error) {
+	err = m.connection.Table(enums.MigrationsTable).Order("version").Find(&applied).Error
+
+	return applied, err
+}
+
+func (m *Migrator) getPending(migrations []Migration, applied []AppliedMigration) (pending []Migration) {
+	for _, step := range m.planUp(migrations, applied, math.MaxUint64) {
+		pending = append(pending, step.migration)
+	}
+
+	return pending
+}
+
+// ForceUnlock releases the lock left by a migration process that was interrupted before finishing.
+func (m *Migrator) ForceUnlock() error {
+	return m.connection.Table(enums.MigrationsLockTable).
+		Where("id = ?", enums.MigrationsLockID).Delete(&migrationLock{}).Error
+}
+
+func (m *Migrator) migrate(plan func([]Migration, []AppliedMigration) ([]step, error)) ([]Migration, error) {
+	if !m.dryRun {
+		if err := m.lock(); err != nil {
+			return nil, err
+		}
+
+		defer m.unlock()
+	}
+
+	migrations, applied, err := m.loadState(true)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := plan(migrations, applied)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.execute(steps)
+}
+
+// loadState returns the migrations of the source and the applied ones, adopting the migrations applied by
+// golang-migrate, which are recorded into the migrations table only when persist is enabled.
+func (m *Migrator) loadState(persist bool) ([]Migration, []AppliedMigration, error) {
+	migrations, err := LoadMigrations(m.source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied, err := m.Applied()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if applied, err = m.adoptLegacy(migrations, applied, persist); err != nil {
+		return nil, nil, err
+	}
+
+	return migrations, applied, m.verifyChecksums(migrations, applied)
+}
+
+// adoptLegacy considers the migrations until the version of the golang-migrate table as applied, which is done only
+// while the migrator has no applied migrations, so databases migrated by the migration scripts are not migrated
+// again. A dirty golang-migrate version returns enums.ErrorLegacyMigrationDirty, since it's not known which changes
+// of the failed migration were applied.
+func (m *Migrator) adoptLegacy(migrations []Migration, applied []AppliedMigration,
+	persist bool) ([]AppliedMigration, error) {
+	if len(applied) > 0 || !m.connection.Migrator().HasTable(enums.LegacyMigrationsTable) {
+		return applied, nil
+	}
+
+	var legacy legacyMigration
+
+	result := m.connection.Table(enums.LegacyMigrationsTable).Limit(1).Find(&legacy)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return applied, result.Error
+	}
+
+	if legacy.Dirty {
+		return nil, fmt.Errorf("%w: version %d", enums.ErrorLegacyMigrationDirty, legacy.Version)
+	}
+
+	return m.baseline(migrations, legacy.Version, persist && !m.dryRun)
+}
+
+func (m *Migrator) baseline(migrations []Migration, version uint64, persist bool) ([]AppliedMigration, error) {
+	var applied []AppliedMigration
+
+	for _, migration := range migrations {
+		if migration.Version <= version {
+			applied = append(applied, AppliedMigration{Version: migration.Version, Name: migration.Name,
+				Checksum: migration.Checksum, AppliedAt: time.Now()})
+		}
+	}
+
+	if !persist || len(applied) == 0 {
+		return applied, nil
+	}
+
+	logger.LogInfo(fmt.Sprintf(enums.MessageAdoptingLegacy, len(applied), version))
+
+	return applied, m.connection.Table(enums.MigrationsTable).Create(&applied).Error
+}
+
+func (m *Migrator) verifyChecksums(migrations []Migration, applied []AppliedMigration) error {
+	byVersion := m.mapByVersion(migrations)
+
+	for _, appliedMigration := range applied {
+		migration, ok := byVersion[appliedMigration.Version]
+		if ok && migration.Checksum != appliedMigration.Checksum {
+			return fmt.Errorf("%w: %d_%s", enums.ErrorMigrationChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) planUp(migrations []Migration, applied []AppliedMigration, version uint64) (steps []step) {
+	appliedVersions := map[uint64]bool{}
+	for _, appliedMigration := range applied {
+		appliedVersions[appliedMigration.Version] = true
+	}
+
+	for _, migration := range migrations {
+		if migration.Version <= version && !appliedVersions[migration.Version] {
+			steps = append(steps, step{migration: migration, up: true})
+		}
+	}
+
+	return steps
+}
+
+func (m *Migrator) planDown(migrations []Migration, toRevert []AppliedMigration) (steps []step, err error) {
+	byVersion := m.mapByVersion(migrations)
+
+	for _, appliedMigration := range toRevert {
+		migration, ok := byVersion[appliedMigration.Version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d_%s", enums.ErrorMigrationNotFound, appliedMigration.Version,
+				appliedMigration.Name)
+		}
+
+		if migration.Down == "" {
+			return nil, fmt.Errorf("%w: %d_%s", enums.ErrorMigrationWithoutDown, migration.Version, migration.Name)
+		}
+
+		steps = append(steps, step{migration: migration})
+	}
+
+	return steps, nil
+}
+
+func (m *Migrator) mapByVersion(migrations []Migration) map[uint64]Migration {
+	byVersion := make(map[uint64]Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	return byVersion
+}
+
+func (m *Migrator) execute(steps []step) ([]Migration, error) {
+	executed := make([]Migration, 0, len(steps))
+	if len(steps) == 0 {
+		logger.LogInfo(enums.MessageNoMigrationsToApply)
+	}
+
+	for _, current := range steps {
+		if err := m.executeStep(current); err != nil {
+			return executed, err
+		}
+
+		executed = append(executed, current.migration)
+	}
+
+	return executed, nil
+}
+
+func (m *Migrator) executeStep(current step) error {
+	if m.dryRun {
+		logger.LogInfo(fmt.Sprintf(enums.MessageDryRunMigration, current.migration.Version, current.migration.Name,
+			current.direction()))
+
+		return nil
+	}
+
+	if current.up {
+		return m.apply(current.migration)
+	}
+
+	return m.revert(current.migration)
+}
+
+func (m *Migrator) apply(migration Migration) error {
+	logger.LogInfo(fmt.Sprintf(enums.MessageApplyingMigration, migration.Version, migration.Name))
+
+	return m.connection.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(enums.MigrationsTable).Create(&AppliedMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: time.Now(),
+		}).Error
+	})
+}
+
+func (m *Migrator) revert(migration Migration) error {
+	logger.LogInfo(fmt.Sprintf(enums.MessageRevertingMigration, migration.Version, migration.Name))
+
+	return m.connection.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(enums.MigrationsTable).Where("version = ?", migration.Version).
+			Delete(&AppliedMigration{}).Error
+	})
+}
+
+func (m *Migrator) createTables() error {
+	if err := m.connection.Exec(enums.CreateMigrationsTable).Error; err != nil {
+		return err
+	}
+
+	return m.connection.Exec(enums.CreateMigrationsLockTable).Error
+}
+
+func (m *Migrator) lock() error {
+	if err := m.createTables(); err != nil {
+		return err
+	}
+
+	err := m.connection.Table(enums.MigrationsLockTable).
+		Create(&migrationLock{ID: enums.MigrationsLockID, LockedAt: time.Now()}).Error
+	if err != nil {
+		return fmt.Errorf("%w: %v", enums.ErrorMigrationLocked, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) unlock() {
+	logger.LogError(enums.MessageFailedToUnlock, m.ForceUnlock())
+}
+
+func (s *step) direction() string {
+	if s.up {
+		return enums.DirectionUp
+	}
+
+	return enums.DirectionDown
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/logger"
+)
+
+func (d *database) setPoolConfig(connection *gorm.DB) {
+	sqlDB, err := connection.DB()
+	if err != nil {
+		logger.LogError(enums.MessageFailedToSetPoolConfig, err)
+
+		return
+	}
+
+	sqlDB.SetMaxOpenConns(d.config.GetMaxOpenConns())
+	sqlDB.SetMaxIdleConns(d.config.GetMaxIdleConns())
+	sqlDB.SetConnMaxLifetime(d.config.GetConnMaxLifetime())
+	sqlDB.SetConnMaxIdleTime(d.config.GetConnMaxIdleTime())
+}
+
+// getConnectionPools returns the sql pools of the database by name, being "write" the write connection and
+// "read_0", "read_1"... the read replicas in the same order of the configured read uris.
+func (d *database) getConnectionPools() map[string]*sql.DB {
+	pools := map[string]*sql.DB{}
+
+	if d.connectionWrite != nil {
+		if sqlDB, err := d.connectionWrite.DB(); err == nil {
+			pools[enums.PoolNameWrite] = sqlDB
+		}
+	}
+
+	for index, connection := range d.getReadConnections() {
+		if connection == nil {
+			continue
+		}
+
+		if sqlDB, err := connection.DB(); err == nil {
+			pools[fmt.Sprintf(enums.PoolNameRead, index)] = sqlDB
+		}
+	}
+
+	return pools
+}
+
+// Stats returns the statistics of every connection pool by name, being "write" the write connection and "read_0",
+// "read_1"... the read replicas. Connections not created by NewDatabaseReadAndWrite returns an empty map.
+func (c *Connection) Stats() map[string]sql.DBStats {
+	stats := map[string]sql.DBStats{}
+	if c.database == nil {
+		return stats
+	}
+
+	for name, pool := range c.database.getConnectionPools() {
+		stats[name] = pool.Stats()
+	}
+
+	return stats
+}
+
+// RegisterMetrics registers the go_sql_* metrics of every connection pool into the prometheus registerer, using
+// the pool name as the db_name label. Usage example: connection.RegisterMetrics(prometheus.DefaultRegisterer)
+func (c *Connection) RegisterMetrics(registerer prometheus.Registerer) error {
+	if c.database == nil {
+		return nil
+	}
+
+	for name, pool := range c.database.getConnectionPools() {
+		if err := registerer.Register(collectors.NewDBStatsCollector(pool, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/config"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+func getMockedPoolDatabase(t *testing.T) *database {
+	writeDB, _, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	readDB, _, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	read := getMockedConnection(readDB)
+
+	return &database{
+		config:          config.NewDatabaseConfig(),
+		connectionWrite: getMockedConnection(writeDB),
+		connectionRead:  read,
+		replicas:        newReplicaRouter([]*gorm.DB{read}, enums.ReadRoutingRoundRobin),
+	}
+}
+
+func TestSetPoolConfig(t *testing.T) {
+	t.Run("should success set the pool config", func(t *testing.T) {
+		database := getMockedPoolDatabase(t)
+		databaseConfig := config.NewDatabaseConfig()
+		databaseConfig.SetMaxOpenConns(5)
+		databaseConfig.SetConnMaxLifetime(time.Minute)
+		database.config = databaseConfig
+
+		database.setPoolConfig(database.connectionWrite)
+
+		sqlDB, err := database.connectionWrite.DB()
+		assert.NoError(t, err)
+		assert.Equal(t, 5, sqlDB.Stats().MaxOpenConnections)
+	})
+}
+
+func TestStats(t *testing.T) {
+	t.Run("should return the stats of every pool", func(t *testing.T) {
+		connection := getMockedPoolDatabase(t).setConnections()
+
+		stats := connection.Stats()
+
+		assert.Len(t, stats, 2)
+		assert.Contains(t, stats, "write")
+		assert.Contains(t, stats, "read_0")
+	})
+
+	t.Run("should return empty stats when not created by the database service", func(t *testing.T) {
+		connection := &Connection{Read: &Mock{}, Write: &Mock{}}
+
+		assert.Empty(t, connection.Stats())
+	})
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	t.Run("should success register the pool metrics", func(t *testing.T) {
+		connection := getMockedPoolDatabase(t).setConnections()
+		registry := prometheus.NewRegistry()
+
+		assert.NoError(t, connection.RegisterMetrics(registry))
+
+		metrics, err := registry.Gather()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, metrics)
+	})
+
+	t.Run("should return error when metrics are already registered", func(t *testing.T) {
+		connection := getMockedPoolDatabase(t).setConnections()
+		registry := prometheus.NewRegistry()
+
+		assert.NoError(t, connection.RegisterMetrics(registry))
+		assert.Error(t, connection.RegisterMetrics(registry))
+	})
+
+	t.Run("should do nothing when not created by the database service", func(t *testing.T) {
+		connection := &Connection{Read: &Mock{}, Write: &Mock{}}
+
+		assert.NoError(t, connection.RegisterMetrics(prometheus.NewRegistry()))
+	})
+}
@@ -0,0 +1,302 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+// identifierRegex accepts a column or table name optionally prefixed by its table, like "severity" or
+// "vulnerabilities.severity".
+var identifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Apply validates the query and translates it into the gorm query received, returning an error without touching
+// the database when something is invalid.
+func (q *Query) Apply(db *gorm.DB) (*gorm.DB, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	db = q.applyJoins(db)
+	db = q.applyConditions(db)
+	db = q.applySeek(db)
+	db = q.applyOrders(db)
+
+	return q.applyFieldsAndLimits(db), nil
+}
+
+func (q *Query) Validate() error {
+	for index := range q.conditions {
+		if err := q.conditions[index].Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, order := range q.orders {
+		if err := order.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(q.seek) > 0 && len(q.seek) != len(q.orders) {
+		return enums.ErrorInvalidQuerySeek
+	}
+
+	return q.validateFieldsAndJoins()
+}
+
+func (q *Query) validateFieldsAndJoins() error {
+	for _, field := range q.fields {
+		if err := validateIdentifier(field); err != nil {
+			return err
+		}
+	}
+
+	for _, join := range q.joins {
+		if err := join.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (q *Query) applyJoins(db *gorm.DB) *gorm.DB {
+	for _, join := range q.joins {
+		db = db.Joins(fmt.Sprintf("%s JOIN ? ON ? = ?", join.Kind), clause.Table{Name: join.Table},
+			clause.Column{Name: join.LeftField}, clause.Column{Name: join.RightField})
+	}
+
+	return db
+}
+
+func (q *Query) applyConditions(db *gorm.DB) *gorm.DB {
+	for index := range q.conditions {
+		db = db.Where(q.conditions[index].Expression())
+	}
+
+	return db
+}
+
+// applySeek adds the keyset condition, which for the orders (a, b) and values (x, y) results in
+// "a > x OR (a = x AND b > y)", using less than instead of greater than for descending orders.
+func (q *Query) applySeek(db *gorm.DB) *gorm.DB {
+	if len(q.seek) == 0 {
+		return db
+	}
+
+	alternatives := make([]clause.Expression, 0, len(q.orders))
+	for index := range q.orders {
+		alternatives = append(alternatives, q.seekExpression(index))
+	}
+
+	return db.Where(clause.Or(alternatives...))
+}
+
+func (q *Query) seekExpression(index int) clause.Expression {
+	expressions := make([]clause.Expression, 0, index+1)
+	for previous := 0; previous < index; previous++ {
+		expressions = append(expressions, clause.Eq{
+			Column: clause.Column{Name: q.orders[previous].Field},
+			Value:  q.seek[previous],
+		})
+	}
+
+	column := clause.Column{Name: q.orders[index].Field}
+	if q.orders[index].Direction == Descending {
+		return clause.And(append(expressions, clause.Lt{Column: column, Value: q.seek[index]})...)
+	}
+
+	return clause.And(append(expressions, clause.Gt{Column: column, Value: q.seek[index]})...)
+}
+
+func (q *Query) applyOrders(db *gorm.DB) *gorm.DB {
+	for _, order := range q.orders {
+		db = db.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: order.Field},
+			Desc:   order.Direction == Descending,
+		})
+	}
+
+	return db
+}
+
+func (q *Query) applyFieldsAndLimits(db *gorm.DB) *gorm.DB {
+	if len(q.fields) > 0 {
+		db = db.Select(q.fields)
+	}
+
+	if q.limit > 0 {
+		db = db.Limit(q.limit)
+	}
+
+	if q.offset > 0 {
+		db = db.Offset(q.offset)
+	}
+
+	return db
+}
+
+func (c *Condition) Validate() error {
+	if c.IsGroup() {
+		for index := range c.Alternatives {
+			if err := c.Alternatives[index].Validate(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := validateIdentifier(c.Field); err != nil {
+		return err
+	}
+
+	if !c.Operator.IsValid() {
+		return fmt.Errorf("%w: %s", enums.ErrorInvalidQueryOperator, c.Operator)
+	}
+
+	return c.validateValue()
+}
+
+func (c *Condition) validateValue() error {
+	switch c.Operator {
+	case In, NotIn:
+		return c.validateValueLength(func(length int) bool { return length > 0 })
+	case Between:
+		return c.validateValueLength(func(length int) bool { return length == 2 })
+	case Like, NotLike:
+		if _, ok := c.Value.(string); !ok {
+			return fmt.Errorf("%w: %s", enums.ErrorInvalidQueryValue, c.Field)
+		}
+	}
+
+	return nil
+}
+
+func (c *Condition) validateValueLength(isValidLength func(length int) bool) error {
+	value := reflect.ValueOf(c.Value)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array || !isValidLength(value.Len()) {
+		return fmt.Errorf("%w: %s", enums.ErrorInvalidQueryValue, c.Field)
+	}
+
+	return nil
+}
+
+// Expression returns the gorm clause expression of the condition. The condition should be validated before.
+func (c *Condition) Expression() clause.Expression {
+	if c.IsGroup() {
+		expressions := make([]clause.Expression, 0, len(c.Alternatives))
+		for index := range c.Alternatives {
+			expressions = append(expressions, c.Alternatives[index].Expression())
+		}
+
+		return clause.Or(expressions...)
+	}
+
+	return c.comparisonExpression()
+}
+
+//nolint:gocyclo,exhaustive // every operator needs its own expression and all of them were validated before
+func (c *Condition) comparisonExpression() clause.Expression {
+	column := clause.Column{Name: c.Field}
+
+	switch c.Operator {
+	case NotEqual:
+		return clause.Neq{Column: column, Value: c.Value}
+	case GreaterThan:
+		return clause.Gt{Column: column, Value: c.Value}
+	case GreaterOrEqual:
+		return clause.Gte{Column: column, Value: c.Value}
+	case LessThan:
+		return clause.Lt{Column: column, Value: c.Value}
+	case LessOrEqual:
+		return clause.Lte{Column: column, Value: c.Value}
+	case In, NotIn:
+		return c.inExpression(column)
+	case Like:
+		return clause.Like{Column: column, Value: c.Value}
+	case NotLike:
+		return clause.Not(clause.Like{Column: column, Value: c.Value})
+	case Between:
+		values := toInterfaceSlice(c.Value)
+		return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []interface{}{column, values[0], values[1]}}
+	case IsNull, IsNotNull:
+		return clause.Expr{SQL: fmt.Sprintf("? %s", c.Operator), Vars: []interface{}{column}}
+	}
+
+	return clause.Eq{Column: column, Value: c.Value}
+}
+
+func (c *Condition) inExpression(column clause.Column) clause.Expression {
+	expression := clause.IN{Column: column, Values: toInterfaceSlice(c.Value)}
+	if c.Operator == NotIn {
+		return clause.Not(expression)
+	}
+
+	return expression
+}
+
+func (o *Order) Validate() error {
+	if err := validateIdentifier(o.Field); err != nil {
+		return err
+	}
+
+	if !o.Direction.IsValid() {
+		return fmt.Errorf("%w: %s", enums.ErrorInvalidQueryDirection, o.Direction)
+	}
+
+	return nil
+}
+
+func (j *Join) Validate() error {
+	if !j.Kind.IsValid() {
+		return fmt.Errorf("%w: %s", enums.ErrorInvalidQueryJoin, j.Kind)
+	}
+
+	for _, identifier := range []string{j.Table, j.LeftField, j.RightField} {
+		if err := validateIdentifier(identifier); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateIdentifier(identifier string) error {
+	if !identifierRegex.MatchString(identifier) {
+		return fmt.Errorf("%w: %q", enums.ErrorInvalidQueryField, identifier)
+	}
+
+	return nil
+}
+
+func toInterfaceSlice(value interface{}) []interface{} {
+	reflectValue := reflect.ValueOf(value)
+
+	values := make([]interface{}, 0, reflectValue.Len())
+	for index := 0; index < reflectValue.Len(); index++ {
+		values = append(values, reflectValue.Index(index).Interface())
+	}
+
+	return values
+}
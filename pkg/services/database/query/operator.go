@@ -0,0 +1,87 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+// Operator represents the comparison used by a condition between a field and its value.
+type Operator string
+
+const (
+	Equal          Operator = "="
+	NotEqual       Operator = "<>"
+	GreaterThan    Operator = ">"
+	GreaterOrEqual Operator = ">="
+	LessThan       Operator = "<"
+	LessOrEqual    Operator = "<="
+	In             Operator = "IN"
+	NotIn          Operator = "NOT IN"
+	Like           Operator = "LIKE"
+	NotLike        Operator = "NOT LIKE"
+	Between        Operator = "BETWEEN"
+	IsNull         Operator = "IS NULL"
+	IsNotNull      Operator = "IS NOT NULL"
+)
+
+// Direction represents the sort direction of an order by field.
+type Direction string
+
+const (
+	Ascending  Direction = "ASC"
+	Descending Direction = "DESC"
+)
+
+// JoinKind represents the kind of join made with another table.
+type JoinKind string
+
+const (
+	InnerJoin JoinKind = "INNER"
+	LeftJoin  JoinKind = "LEFT"
+	RightJoin JoinKind = "RIGHT"
+)
+
+func (o Operator) IsValid() bool {
+	for _, operator := range Values() {
+		if o == operator {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d Direction) IsValid() bool {
+	return d == Ascending || d == Descending
+}
+
+func (j JoinKind) IsValid() bool {
+	return j == InnerJoin || j == LeftJoin || j == RightJoin
+}
+
+func Values() []Operator {
+	return []Operator{
+		Equal,
+		NotEqual,
+		GreaterThan,
+		GreaterOrEqual,
+		LessThan,
+		LessOrEqual,
+		In,
+		NotIn,
+		Like,
+		NotLike,
+		Between,
+		IsNull,
+		IsNotNull,
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+// Query is a composable specification of a read made into the database. All the fields and tables are validated
+// before being used and the values are always sent as bind parameters, so it is safe to build a query from user input
+// without writing raw sql.
+//
+// Usage example:
+//
+//	query.New().
+//	  Where("severity", query.In, []string{"CRITICAL", "HIGH"}).
+//	  Where("created_at", query.Between, []time.Time{startDate, endDate}).
+//	  WhereAny(query.NewCondition("type", query.Equal, "Vulnerability"),
+//	    query.NewCondition("type", query.Equal, "Risk Accepted")).
+//	  OrderBy("created_at", query.Descending)
+type Query struct {
+	conditions []Condition
+	orders     []Order
+	fields     []string
+	joins      []Join
+	seek       []interface{}
+	limit      int
+	offset     int
+}
+
+// Condition compares a field with a value. When it contains any alternatives, the condition is an OR group and the
+// field, operator and value are ignored.
+type Condition struct {
+	Field        string
+	Operator     Operator
+	Value        interface{}
+	Alternatives []Condition
+}
+
+type Order struct {
+	Field     string
+	Direction Direction
+}
+
+// Join represents a join with another table, where the left field is compared with the right field.
+type Join struct {
+	Kind       JoinKind
+	Table      string
+	LeftField  string
+	RightField string
+}
+
+func New() *Query {
+	return &Query{}
+}
+
+func NewCondition(field string, operator Operator, value interface{}) Condition {
+	return Condition{
+		Field:    field,
+		Operator: operator,
+		Value:    value,
+	}
+}
+
+// Where adds a condition that must be satisfied together with all the others.
+func (q *Query) Where(field string, operator Operator, value interface{}) *Query {
+	q.conditions = append(q.conditions, NewCondition(field, operator, value))
+
+	return q
+}
+
+// WhereAny adds a group of conditions where at least one of them must be satisfied.
+func (q *Query) WhereAny(conditions ...Condition) *Query {
+	q.conditions = append(q.conditions, Condition{Alternatives: conditions})
+
+	return q
+}
+
+func (q *Query) OrderBy(field string, direction Direction) *Query {
+	q.orders = append(q.orders, Order{Field: field, Direction: direction})
+
+	return q
+}
+
+func (q *Query) Select(fields ...string) *Query {
+	q.fields = append(q.fields, fields...)
+
+	return q
+}
+
+func (q *Query) Join(kind JoinKind, table, leftField, rightField string) *Query {
+	q.joins = append(q.joins, Join{Kind: kind, Table: table, LeftField: leftField, RightField: rightField})
+
+	return q
+}
+
+// Seek filters the records placed after the received values of the order by fields, following their directions. It
+// is used by keyset pagination, so the values must match the order by fields and the last of them should be unique.
+func (q *Query) Seek(values ...interface{}) *Query {
+	q.seek = values
+
+	return q
+}
+
+func (q *Query) Limit(limit int) *Query {
+	q.limit = limit
+
+	return q
+}
+
+func (q *Query) Offset(offset int) *Query {
+	q.offset = offset
+
+	return q
+}
+
+func (q *Query) GetConditions() []Condition {
+	return q.conditions
+}
+
+func (q *Query) GetOrders() []Order {
+	return q.orders
+}
+
+func (q *Query) GetFields() []string {
+	return q.fields
+}
+
+func (q *Query) GetJoins() []Join {
+	return q.joins
+}
+
+func (q *Query) GetSeek() []interface{} {
+	return q.seek
+}
+
+func (q *Query) GetLimit() int {
+	return q.limit
+}
+
+func (q *Query) GetOffset() int {
+	return q.offset
+}
+
+// Clone returns a copy of the query that can be changed without affecting the original one.
+func (q *Query) Clone() *Query {
+	return &Query{
+		conditions: append([]Condition{}, q.conditions...),
+		orders:     append([]Order{}, q.orders...),
+		fields:     append([]string{}, q.fields...),
+		joins:      append([]Join{}, q.joins...),
+		seek:       append([]interface{}{}, q.seek...),
+		limit:      q.limit,
+		offset:     q.offset,
+	}
+}
+
+// IsGroup returns true when the condition is an OR group of alternatives.
+func (c *Condition) IsGroup() bool {
+	return len(c.Alternatives) > 0
+}
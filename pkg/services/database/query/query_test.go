@@ -0,0 +1,219 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+type testEntity struct {
+	Severity string
+}
+
+func getDryRunConnection(t *testing.T) *gorm.DB {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	connection, err := gorm.Open(postgres.New(postgres.Config{Conn: db, PreferSimpleProtocol: true}),
+		&gorm.Config{DryRun: true})
+	assert.NoError(t, err)
+
+	return connection
+}
+
+func buildSQL(t *testing.T, query *Query) (string, []interface{}) {
+	statement, err := query.Apply(getDryRunConnection(t).Table("vulnerabilities"))
+	assert.NoError(t, err)
+
+	result := statement.Find(&[]testEntity{})
+
+	return result.Statement.SQL.String(), result.Statement.Vars
+}
+
+func TestApply(t *testing.T) {
+	t.Run("should build query with and conditions", func(t *testing.T) {
+		now := time.Now()
+
+		sql, vars := buildSQL(t, New().
+			Where("severity", In, []string{"CRITICAL", "HIGH"}).
+			Where("created_at", Between, []time.Time{now, now}).
+			Where("file", Like, "%.go").
+			Where("commit_email", IsNotNull, nil))
+
+		assert.Equal(t, `SELECT * FROM "vulnerabilities" WHERE "severity" IN ($1,$2) AND `+
+			`("created_at" BETWEEN $3 AND $4) AND "file" LIKE $5 AND "commit_email" IS NOT NULL`, sql)
+		assert.Equal(t, []interface{}{"CRITICAL", "HIGH", now, now, "%.go"}, vars)
+	})
+
+	t.Run("should build query with or group", func(t *testing.T) {
+		sql, vars := buildSQL(t, New().
+			Where("severity", NotEqual, "INFO").
+			WhereAny(NewCondition("type", Equal, "Vulnerability"), NewCondition("type", Equal, "Risk Accepted")))
+
+		assert.Equal(t, `SELECT * FROM "vulnerabilities" WHERE "severity" <> $1 AND `+
+			`("type" = $2 OR "type" = $3)`, sql)
+		assert.Equal(t, []interface{}{"INFO", "Vulnerability", "Risk Accepted"}, vars)
+	})
+
+	t.Run("should build query with comparison and negation operators", func(t *testing.T) {
+		sql, _ := buildSQL(t, New().
+			Where("line", GreaterThan, 1).
+			Where("line", GreaterOrEqual, 1).
+			Where("line", LessThan, 10).
+			Where("line", LessOrEqual, 10).
+			Where("language", NotIn, []string{"Leaks"}).
+			Where("file", NotLike, "%_test.go").
+			Where("details", IsNull, nil))
+
+		assert.Equal(t, `SELECT * FROM "vulnerabilities" WHERE "line" > $1 AND "line" >= $2 AND "line" < $3 AND `+
+			`"line" <= $4 AND "language" <> $5 AND "file" NOT LIKE $6 AND "details" IS NULL`, sql)
+	})
+
+	t.Run("should build query with select, join, order, limit and offset", func(t *testing.T) {
+		sql, _ := buildSQL(t, New().
+			Select("vulnerabilities.severity").
+			Join(InnerJoin, "analysis_vulnerabilities", "analysis_vulnerabilities.vulnerability_id",
+				"vulnerabilities.vulnerability_id").
+			OrderBy("vulnerabilities.severity", Descending).
+			OrderBy("vulnerabilities.file", Ascending).
+			Limit(10).
+			Offset(20))
+
+		assert.Equal(t, `SELECT vulnerabilities.severity FROM "vulnerabilities" INNER JOIN "analysis_vulnerabilities" `+
+			`ON "analysis_vulnerabilities"."vulnerability_id" = "vulnerabilities"."vulnerability_id" `+
+			`ORDER BY "vulnerabilities"."severity" DESC,"vulnerabilities"."file" LIMIT 10 OFFSET 20`, sql)
+	})
+
+	t.Run("should build query with keyset seek following order directions", func(t *testing.T) {
+		sql, vars := buildSQL(t, New().
+			Where("severity", Equal, "HIGH").
+			OrderBy("created_at", Descending).
+			OrderBy("vulnerability_id", Ascending).
+			Seek("2021-12-30", "id"))
+
+		assert.Equal(t, `SELECT * FROM "vulnerabilities" WHERE "severity" = $1 AND ("created_at" < $2 OR `+
+			`("created_at" = $3 AND "vulnerability_id" > $4)) ORDER BY "created_at" DESC,"vulnerability_id"`, sql)
+		assert.Equal(t, []interface{}{"HIGH", "2021-12-30", "2021-12-30", "id"}, vars)
+	})
+
+	t.Run("should return error when seek values does not match orders", func(t *testing.T) {
+		_, err := New().OrderBy("created_at", Descending).Seek("a", "b").Apply(getDryRunConnection(t))
+
+		assert.True(t, errors.Is(err, enums.ErrorInvalidQuerySeek))
+	})
+
+	t.Run("should return error when field name is invalid", func(t *testing.T) {
+		_, err := New().Where("severity = 'HIGH' OR 1=1 --", Equal, "").Apply(getDryRunConnection(t))
+
+		assert.True(t, errors.Is(err, enums.ErrorInvalidQueryField))
+	})
+
+	t.Run("should return error when field name inside or group is invalid", func(t *testing.T) {
+		_, err := New().WhereAny(NewCondition("1severity", Equal, "")).Apply(getDryRunConnection(t))
+
+		assert.True(t, errors.Is(err, enums.ErrorInvalidQueryField))
+	})
+
+	t.Run("should return error when operator is invalid", func(t *testing.T) {
+		_, err := New().Where("severity", "; DROP", "").Apply(getDryRunConnection(t))
+
+		assert.True(t, errors.Is(err, enums.ErrorInvalidQueryOperator))
+	})
+
+	t.Run("should return error when value is invalid for the operator", func(t *testing.T) {
+		for _, query := range []*Query{
+			New().Where("severity", In, "HIGH"),
+			New().Where("severity", In, []string{}),
+			New().Where("created_at", Between, []int{1}),
+			New().Where("file", Like, 1),
+		} {
+			_, err := query.Apply(getDryRunConnection(t))
+
+			assert.True(t, errors.Is(err, enums.ErrorInvalidQueryValue))
+		}
+	})
+
+	t.Run("should return error when order is invalid", func(t *testing.T) {
+		_, err := New().OrderBy("severity", "RANDOM").Apply(getDryRunConnection(t))
+		assert.True(t, errors.Is(err, enums.ErrorInvalidQueryDirection))
+
+		_, err = New().OrderBy("", Ascending).Apply(getDryRunConnection(t))
+		assert.True(t, errors.Is(err, enums.ErrorInvalidQueryField))
+	})
+
+	t.Run("should return error when select field is invalid", func(t *testing.T) {
+		_, err := New().Select("count(*)").Apply(getDryRunConnection(t))
+
+		assert.True(t, errors.Is(err, enums.ErrorInvalidQueryField))
+	})
+
+	t.Run("should return error when join is invalid", func(t *testing.T) {
+		_, err := New().Join("CROSS", "analysis", "a", "b").Apply(getDryRunConnection(t))
+		assert.True(t, errors.Is(err, enums.ErrorInvalidQueryJoin))
+
+		_, err = New().Join(LeftJoin, "analysis;", "a", "b").Apply(getDryRunConnection(t))
+		assert.True(t, errors.Is(err, enums.ErrorInvalidQueryField))
+	})
+}
+
+func TestGetters(t *testing.T) {
+	t.Run("should success get query values", func(t *testing.T) {
+		query := New().Where("severity", Equal, "HIGH").OrderBy("file", Ascending).Select("file").
+			Join(RightJoin, "analysis", "a", "b").Limit(1).Offset(2)
+
+		assert.Len(t, query.GetConditions(), 1)
+		assert.Len(t, query.GetOrders(), 1)
+		assert.Equal(t, []string{"file"}, query.GetFields())
+		assert.Len(t, query.GetJoins(), 1)
+		assert.Equal(t, 1, query.GetLimit())
+		assert.Equal(t, 2, query.GetOffset())
+	})
+}
+
+func TestClone(t *testing.T) {
+	t.Run("should clone query without sharing changes", func(t *testing.T) {
+		query := New().Where("severity", Equal, "HIGH").OrderBy("file", Ascending).Limit(1)
+
+		clone := query.Clone().Where("type", Equal, "Vulnerability").Seek("file").Limit(2)
+
+		assert.Len(t, query.GetConditions(), 1)
+		assert.Empty(t, query.GetSeek())
+		assert.Equal(t, 1, query.GetLimit())
+		assert.Len(t, clone.GetConditions(), 2)
+		assert.Equal(t, []interface{}{"file"}, clone.GetSeek())
+		assert.Equal(t, 2, clone.GetLimit())
+	})
+}
+
+func TestOperatorIsValid(t *testing.T) {
+	t.Run("should return true for all operators values", func(t *testing.T) {
+		for _, operator := range Values() {
+			assert.True(t, operator.IsValid())
+		}
+	})
+
+	t.Run("should return false for unknown operator", func(t *testing.T) {
+		assert.False(t, Operator("test").IsValid())
+	})
+}
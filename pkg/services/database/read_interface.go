@@ -15,6 +15,7 @@
 package database
 
 import (
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/query"
 	"github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
 )
 
@@ -27,4 +28,12 @@ type IDatabaseRead interface {
 	Raw(rawSQL string, entityPointer interface{}, values ...interface{}) response.IResponse
 	FindPreloadWitLimitAndPage(entityPointer interface{}, where map[string]interface{},
 		preloads map[string][]interface{}, table string, limit, page int) response.IResponse
+	FindByQuery(entityPointer interface{}, spec *query.Query, table string) response.IResponse
+	FirstByQuery(entityPointer interface{}, spec *query.Query, table string) response.IResponse
+	FindByCursor(entityPointer interface{}, spec *query.Query, cursor string, size int,
+		table string) response.IResponse
+	FindAuditLogs(table string, spec *query.Query) response.IResponse
+	Iterate(entityPointer interface{}, spec *query.Query, table string, callback func() error) response.IResponse
+	FindInBatches(entitiesPointer interface{}, spec *query.Query, table string, size int,
+		callback func(batch int) error) response.IResponse
 }
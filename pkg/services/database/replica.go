@@ -0,0 +1,107 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/logger"
+)
+
+// replicaRouter chooses which read replica is used by each read, distributing them in round-robin or sending them
+// to the replica with the smallest replication lag. The lag is measured again only after the refresh interval.
+type replicaRouter struct {
+	connections     []*gorm.DB
+	policy          string
+	counter         uint64
+	mutex           sync.Mutex
+	lags            []time.Duration
+	lagsRefreshedAt time.Time
+	refreshInterval time.Duration
+}
+
+func newReplicaRouter(connections []*gorm.DB, policy string) *replicaRouter {
+	return &replicaRouter{
+		connections:     connections,
+		policy:          policy,
+		refreshInterval: enums.DefaultReplicaLagRefreshSecs * time.Second,
+	}
+}
+
+func (r *replicaRouter) next() *gorm.DB {
+	if len(r.connections) == 1 {
+		return r.connections[0]
+	}
+
+	if r.policy == enums.ReadRoutingLeastLag {
+		return r.connections[r.leastLagIndex()]
+	}
+
+	return r.connections[(atomic.AddUint64(&r.counter, 1)-1)%uint64(len(r.connections))]
+}
+
+func (r *replicaRouter) leastLagIndex() (index int) {
+	lags := r.getReplicationLags()
+
+	for current, lag := range lags {
+		if lag < lags[index] {
+			index = current
+		}
+	}
+
+	return index
+}
+
+// getReplicationLags returns the replication lag of each replica, in the same order of the connections. Replicas
+// that failed to report the lag are returned with the max duration possible.
+func (r *replicaRouter) getReplicationLags() []time.Duration {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.lags == nil || time.Since(r.lagsRefreshedAt) >= r.refreshInterval {
+		r.lags = r.measureReplicationLags()
+		r.lagsRefreshedAt = time.Now()
+	}
+
+	return r.lags
+}
+
+func (r *replicaRouter) measureReplicationLags() []time.Duration {
+	lags := make([]time.Duration, 0, len(r.connections))
+
+	for _, connection := range r.connections {
+		lags = append(lags, r.measureReplicationLag(connection))
+	}
+
+	return lags
+}
+
+func (r *replicaRouter) measureReplicationLag(connection *gorm.DB) time.Duration {
+	var seconds float64
+
+	if err := connection.Raw(enums.QueryReplicationLag).Scan(&seconds).Error; err != nil {
+		logger.LogError(enums.MessageFailedToMeasureReplicationLag, err)
+
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
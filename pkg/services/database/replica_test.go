@@ -0,0 +1,119 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+func TestReplicaRouterNext(t *testing.T) {
+	t.Run("should return the single connection when there is only one replica", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		connection := getMockedConnection(db)
+		router := newReplicaRouter([]*gorm.DB{connection}, enums.ReadRoutingLeastLag)
+
+		assert.Equal(t, connection, router.next())
+	})
+
+	t.Run("should distribute the reads in round-robin", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		first := getMockedConnection(db)
+		second := getMockedConnection(db)
+		router := newReplicaRouter([]*gorm.DB{first, second}, enums.ReadRoutingRoundRobin)
+
+		assert.Same(t, first, router.next())
+		assert.Same(t, second, router.next())
+		assert.Same(t, first, router.next())
+	})
+
+	t.Run("should send the reads to the replica with the least lag", func(t *testing.T) {
+		firstDB, firstMock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		secondDB, secondMock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		firstMock.ExpectQuery("pg_last_xact_replay_timestamp").
+			WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(3.5))
+		secondMock.ExpectQuery("pg_last_xact_replay_timestamp").
+			WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(0.2))
+
+		second := getMockedConnection(secondDB)
+		router := newReplicaRouter([]*gorm.DB{getMockedConnection(firstDB), second}, enums.ReadRoutingLeastLag)
+
+		assert.Same(t, second, router.next())
+		assert.Same(t, second, router.next())
+		assert.NoError(t, firstMock.ExpectationsWereMet())
+		assert.NoError(t, secondMock.ExpectationsWereMet())
+	})
+}
+
+func TestReplicaRouterGetReplicationLags(t *testing.T) {
+	t.Run("should return max duration when failed to measure the lag", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectQuery("pg_last_xact_replay_timestamp").WillReturnError(errors.New("test"))
+
+		router := newReplicaRouter([]*gorm.DB{getMockedConnection(db)}, enums.ReadRoutingLeastLag)
+
+		assert.Equal(t, []time.Duration{time.Duration(math.MaxInt64)}, router.getReplicationLags())
+	})
+
+	t.Run("should measure the lags again after the refresh interval", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		mock.ExpectQuery("pg_last_xact_replay_timestamp").
+			WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(1))
+		mock.ExpectQuery("pg_last_xact_replay_timestamp").
+			WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(2))
+
+		router := newReplicaRouter([]*gorm.DB{getMockedConnection(db)}, enums.ReadRoutingLeastLag)
+		router.refreshInterval = 0
+
+		assert.Equal(t, []time.Duration{time.Second}, router.getReplicationLags())
+		assert.Equal(t, []time.Duration{2 * time.Second}, router.getReplicationLags())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetConnectionRead(t *testing.T) {
+	t.Run("should use the replicas when configured", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		replica := getMockedConnection(db)
+		database := &database{
+			connectionRead: getMockedConnection(db),
+			replicas:       newReplicaRouter([]*gorm.DB{replica}, enums.ReadRoutingRoundRobin),
+		}
+
+		assert.Same(t, replica, database.getConnectionRead())
+	})
+}
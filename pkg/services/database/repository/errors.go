@@ -0,0 +1,60 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+const (
+	OperationFindByID  = "find by id"
+	OperationFindWhere = "find where"
+	OperationFindPage  = "find page"
+	OperationCreate    = "create"
+	OperationUpdate    = "update"
+	OperationDelete    = "delete"
+)
+
+// Error is returned by every repository operation that fails, keeping the operation and table where it happened.
+// The original error can still be checked with errors.Is and errors.As.
+type Error struct {
+	Operation string
+	Table     string
+	Err       error
+}
+
+func NewError(operation, table string, err error) *Error {
+	return &Error{
+		Operation: operation,
+		Table:     table,
+		Err:       err,
+	}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("{ERROR_REPOSITORY} failed to %s on table %s: %v", e.Operation, e.Table, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound returns true when the error was caused by the absence of records in the database.
+func IsNotFound(err error) bool {
+	return errors.Is(err, enums.ErrorNotFoundRecords)
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
+)
+
+// Entity is the constraint satisfied by every pointer to a database entity, like *analysis.Analysis or
+// *vulnerability.Vulnerability. The table used by the repository is the one returned by GetTable.
+type Entity[T any] interface {
+	*T
+	GetTable() string
+}
+
+// IRepository is a typed layer on top of the database service, avoiding the need of type assertions over
+// response.IResponse data in every caller.
+type IRepository[T any] interface {
+	FindByID(id interface{}) (*T, error)
+	FindWhere(where map[string]interface{}) ([]T, error)
+	FindPage(where map[string]interface{}, page, size int) ([]T, error)
+	Create(entity *T) error
+	Update(id interface{}, entity *T) error
+	Delete(id interface{}) error
+}
+
+type Repository[T any, P Entity[T]] struct {
+	databaseRead  database.IDatabaseRead
+	databaseWrite database.IDatabaseWrite
+	primaryKey    string
+	table         string
+}
+
+// NewRepository creates a repository of the entity T, using the primary key column to identify a single record.
+// Usage example: repository.NewRepository[analysis.Analysis](connection, "analysis_id")
+func NewRepository[T any, P Entity[T]](connection *database.Connection, primaryKey string) IRepository[T] {
+	return &Repository[T, P]{
+		databaseRead:  connection.Read,
+		databaseWrite: connection.Write,
+		primaryKey:    primaryKey,
+		table:         P(new(T)).GetTable(),
+	}
+}
+
+func (r *Repository[T, P]) FindByID(id interface{}) (*T, error) {
+	entity := new(T)
+
+	result := r.databaseRead.First(entity, r.idFilter(id), r.table)
+	if result.GetError() != nil {
+		return nil, NewError(OperationFindByID, r.table, result.GetError())
+	}
+
+	return entity, nil
+}
+
+// FindWhere returns all records matching the where clause. When no records are found an empty slice is returned
+// instead of an error.
+func (r *Repository[T, P]) FindWhere(where map[string]interface{}) ([]T, error) {
+	var entities []T
+
+	result := r.databaseRead.Find(&entities, where, r.table)
+
+	return r.parseListResponse(OperationFindWhere, &entities, result)
+}
+
+// FindPage returns the records of the page index, starting at 0, containing at most size records. When size is
+// zero the database service default is used.
+func (r *Repository[T, P]) FindPage(where map[string]interface{}, page, size int) ([]T, error) {
+	var entities []T
+
+	result := r.databaseRead.FindPreloadWitLimitAndPage(&entities, where, nil, r.table, size, page)
+
+	return r.parseListResponse(OperationFindPage, &entities, result)
+}
+
+func (r *Repository[T, P]) Create(entity *T) error {
+	result := r.databaseWrite.Create(entity, r.table)
+	if result.GetError() != nil {
+		return NewError(OperationCreate, r.table, result.GetError())
+	}
+
+	return nil
+}
+
+func (r *Repository[T, P]) Update(id interface{}, entity *T) error {
+	result := r.databaseWrite.Update(entity, r.idFilter(id), r.table)
+
+	return r.parseWriteResponse(OperationUpdate, result)
+}
+
+func (r *Repository[T, P]) Delete(id interface{}) error {
+	result := r.databaseWrite.Delete(r.idFilter(id), r.table)
+
+	return r.parseWriteResponse(OperationDelete, result)
+}
+
+func (r *Repository[T, P]) idFilter(id interface{}) map[string]interface{} {
+	return map[string]interface{}{r.primaryKey: id}
+}
+
+func (r *Repository[T, P]) parseListResponse(operation string, entities *[]T,
+	result response.IResponse) ([]T, error) {
+	if err := result.GetErrorExceptNotFound(); err != nil {
+		return nil, NewError(operation, r.table, err)
+	}
+
+	if *entities == nil {
+		return []T{}, nil
+	}
+
+	return *entities, nil
+}
+
+func (r *Repository[T, P]) parseWriteResponse(operation string, result response.IResponse) error {
+	if result.GetError() != nil {
+		return NewError(operation, r.table, result.GetError())
+	}
+
+	if result.GetRowsAffected() == 0 {
+		return NewError(operation, r.table, enums.ErrorNotFoundRecords)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	mockUtils "github.com/Fotkurz/horusec-devkit/pkg/utils/mock"
+)
+
+type Mock[T any] struct {
+	mock.Mock
+}
+
+func (m *Mock[T]) FindByID(_ interface{}) (*T, error) {
+	args := m.MethodCalled("FindByID")
+	return args.Get(0).(*T), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock[T]) FindWhere(_ map[string]interface{}) ([]T, error) {
+	args := m.MethodCalled("FindWhere")
+	return args.Get(0).([]T), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock[T]) FindPage(_ map[string]interface{}, _, _ int) ([]T, error) {
+	args := m.MethodCalled("FindPage")
+	return args.Get(0).([]T), mockUtils.ReturnNilOrError(args, 1)
+}
+
+func (m *Mock[T]) Create(_ *T) error {
+	args := m.MethodCalled("Create")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock[T]) Update(_ interface{}, _ *T) error {
+	args := m.MethodCalled("Update")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
+
+func (m *Mock[T]) Delete(_ interface{}) error {
+	args := m.MethodCalled("Delete")
+	return mockUtils.ReturnNilOrError(args, 0)
+}
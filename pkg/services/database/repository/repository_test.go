@@ -0,0 +1,207 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
+)
+
+func newTestRepository(databaseMock *database.Mock) IRepository[analysis.Analysis] {
+	return NewRepository[analysis.Analysis](&database.Connection{Read: databaseMock, Write: databaseMock},
+		"analysis_id")
+}
+
+func TestNewRepository(t *testing.T) {
+	t.Run("should success create a new repository using entity table", func(t *testing.T) {
+		repository := newTestRepository(&database.Mock{})
+
+		assert.Equal(t, "analysis", repository.(*Repository[analysis.Analysis, *analysis.Analysis]).table)
+	})
+}
+
+func TestFindByID(t *testing.T) {
+	t.Run("should success find entity by id", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+		id := uuid.New()
+
+		databaseMock.On("First").Return(response.NewResponse(1, nil, &analysis.Analysis{ID: id}))
+
+		result, err := newTestRepository(databaseMock).FindByID(id)
+
+		assert.NoError(t, err)
+		assert.Equal(t, id, result.ID)
+	})
+
+	t.Run("should return not found error when entity does not exists", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("First").Return(response.NewResponse(0, enums.ErrorNotFoundRecords, nil))
+
+		result, err := newTestRepository(databaseMock).FindByID(uuid.New())
+
+		assert.Nil(t, result)
+		assert.True(t, IsNotFound(err))
+
+		var repositoryError *Error
+		assert.True(t, errors.As(err, &repositoryError))
+		assert.Equal(t, OperationFindByID, repositoryError.Operation)
+		assert.Equal(t, "analysis", repositoryError.Table)
+	})
+}
+
+func TestFindWhere(t *testing.T) {
+	t.Run("should success find entities", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("Find").Return(response.NewResponse(2, nil,
+			[]analysis.Analysis{{ID: uuid.New()}, {ID: uuid.New()}}))
+
+		result, err := newTestRepository(databaseMock).FindWhere(map[string]interface{}{})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("should return empty slice when not found records", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("Find").Return(response.NewResponse(0, enums.ErrorNotFoundRecords, nil))
+
+		result, err := newTestRepository(databaseMock).FindWhere(map[string]interface{}{})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Empty(t, result)
+	})
+
+	t.Run("should return error when something went wrong", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("Find").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		result, err := newTestRepository(databaseMock).FindWhere(map[string]interface{}{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestFindPage(t *testing.T) {
+	t.Run("should success find entities page", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("FindPreloadWitLimitAndPage").Return(response.NewResponse(1, nil,
+			[]analysis.Analysis{{ID: uuid.New()}}))
+
+		result, err := newTestRepository(databaseMock).FindPage(map[string]interface{}{}, 0, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("should return error when something went wrong", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("FindPreloadWitLimitAndPage").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		_, err := newTestRepository(databaseMock).FindPage(map[string]interface{}{}, 0, 10)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("should success create entity", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("Create").Return(response.NewResponse(1, nil, nil))
+
+		assert.NoError(t, newTestRepository(databaseMock).Create(&analysis.Analysis{}))
+	})
+
+	t.Run("should return error when failed to create", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("Create").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		assert.Error(t, newTestRepository(databaseMock).Create(&analysis.Analysis{}))
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("should success update entity", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("Update").Return(response.NewResponse(1, nil, nil))
+
+		assert.NoError(t, newTestRepository(databaseMock).Update(uuid.New(), &analysis.Analysis{}))
+	})
+
+	t.Run("should return not found error when no rows affected", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("Update").Return(response.NewResponse(0, nil, nil))
+
+		err := newTestRepository(databaseMock).Update(uuid.New(), &analysis.Analysis{})
+
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("should return error when failed to update", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("Update").Return(response.NewResponse(0, errors.New("test"), nil))
+
+		err := newTestRepository(databaseMock).Update(uuid.New(), &analysis.Analysis{})
+
+		assert.Error(t, err)
+		assert.False(t, IsNotFound(err))
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("should success delete entity", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("Delete").Return(response.NewResponse(1, nil, nil))
+
+		assert.NoError(t, newTestRepository(databaseMock).Delete(uuid.New()))
+	})
+
+	t.Run("should return not found error when no rows affected", func(t *testing.T) {
+		databaseMock := &database.Mock{}
+
+		databaseMock.On("Delete").Return(response.NewResponse(0, nil, nil))
+
+		assert.True(t, IsNotFound(newTestRepository(databaseMock).Delete(uuid.New())))
+	})
+}
+
+func TestError(t *testing.T) {
+	t.Run("should return error message with operation and table", func(t *testing.T) {
+		err := NewError(OperationCreate, "analysis", errors.New("test"))
+
+		assert.Equal(t, "{ERROR_REPOSITORY} failed to create on table analysis: test", err.Error())
+	})
+}
@@ -17,6 +17,7 @@ package response
 import (
 	"errors"
 
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/dberror"
 	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
 )
 
@@ -33,9 +34,10 @@ type Response struct {
 	data         interface{}
 }
 
+// NewResponse creates a new response, classifying the driver errors into the dberror kinds, like dberror.ErrDuplicate.
 func NewResponse(rowsAffected int64, err error, data interface{}) IResponse {
 	return &Response{
-		err:          err,
+		err:          dberror.Classify(err),
 		rowsAffected: int(rowsAffected),
 		data:         data,
 	}
@@ -18,8 +18,10 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/jackc/pgconn"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/dberror"
 	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
 )
 
@@ -31,6 +33,12 @@ func TestNewResponse(t *testing.T) {
 		assert.Equal(t, errors.New("test"), databaseResponse.GetError())
 		assert.Equal(t, "data", databaseResponse.GetData())
 	})
+
+	t.Run("should classify the driver errors", func(t *testing.T) {
+		databaseResponse := NewResponse(0, &pgconn.PgError{Code: enums.SQLStateUniqueViolation}, nil)
+
+		assert.ErrorIs(t, databaseResponse.GetError(), dberror.ErrDuplicate)
+	})
 }
 
 func TestGetRowsAffected(t *testing.T) {
@@ -0,0 +1,45 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant"
+)
+
+// WithTenant returns a copy of the connection restricted to the workspace and repository carried by the context,
+// which are set by the authz middleware from the url of the request. Differently from WithContext, the reads and
+// writes of tables with tenant columns fail when the context has no scope, unless it was created with
+// tenant.WithApplicationAdmin. Raw sql and exec are not restricted, so their filters must still be written.
+// Usage example: connection.WithTenant(r.Context()).Read.Find(&vulnerabilities, where, table)
+func (c *Connection) WithTenant(ctx context.Context) *Connection {
+	return c.WithContext(tenant.WithRequiredScope(ctx))
+}
+
+// setTenant registers the callbacks of the tenant scope into the write and read connections.
+func (d *database) setTenant() error {
+	if err := tenant.RegisterCallbacks(d.connectionWrite); err != nil {
+		return err
+	}
+
+	for _, connection := range d.getReadConnections() {
+		if err := tenant.RegisterCallbacks(connection); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
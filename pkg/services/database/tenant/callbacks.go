@@ -0,0 +1,144 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant/enums"
+)
+
+type callbacks struct {
+	columns sync.Map
+}
+
+// RegisterCallbacks registers into the connection the callbacks restricting the reads, preloads, updates and deletes
+// to the scope carried by the context of the statement, and filling the tenant columns of the created records with
+// it. Only tables with the workspace_id or repository_id columns are affected. Raw sql and exec are never changed.
+func RegisterCallbacks(connection *gorm.DB) error {
+	c := &callbacks{}
+
+	err := connection.Callback().Create().Before(enums.GormCreate).Register(enums.CallbackCreateColumns,
+		c.setCreateColumns)
+	if err != nil {
+		return err
+	}
+
+	if err = c.registerReadFilters(connection); err != nil {
+		return err
+	}
+
+	return c.registerWriteFilters(connection)
+}
+
+func (c *callbacks) registerReadFilters(connection *gorm.DB) error {
+	err := connection.Callback().Query().Before(enums.GormQuery).Register(enums.CallbackQueryFilter, c.addFilters)
+	if err != nil {
+		return err
+	}
+
+	return connection.Callback().Row().Before(enums.GormRow).Register(enums.CallbackRowFilter, c.addFilters)
+}
+
+func (c *callbacks) registerWriteFilters(connection *gorm.DB) error {
+	err := connection.Callback().Update().Before(enums.GormUpdate).Register(enums.CallbackUpdateFilter, c.addFilters)
+	if err != nil {
+		return err
+	}
+
+	return connection.Callback().Delete().Before(enums.GormDelete).Register(enums.CallbackDeleteFilter, c.addFilters)
+}
+
+func (c *callbacks) setCreateColumns(db *gorm.DB) {
+	scope, ok := c.getScope(db)
+	if !ok || db.Statement.Schema == nil {
+		return
+	}
+
+	for _, column := range enums.Columns {
+		if id := scope.GetID(column); id != uuid.Nil && db.Statement.Schema.LookUpField(column) != nil {
+			db.Statement.SetColumn(column, id, true)
+		}
+	}
+}
+
+func (c *callbacks) addFilters(db *gorm.DB) {
+	scope, ok := c.getScope(db)
+	if !ok {
+		return
+	}
+
+	for _, column := range enums.Columns {
+		if id := scope.GetID(column); id != uuid.Nil && c.hasColumn(db, column) {
+			db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: column}, Value: id},
+			}})
+		}
+	}
+}
+
+// getScope returns the scope that must be applied into the statement, adding an error when the scope is required
+// and the context has none but the table has tenant columns.
+func (c *callbacks) getScope(db *gorm.DB) (Scope, bool) {
+	ctx := db.Statement.Context
+	if db.Error != nil || IsApplicationAdmin(ctx) {
+		return Scope{}, false
+	}
+
+	scope, ok := GetScope(ctx)
+	if !ok && IsScopeRequired(ctx) && c.hasTenantColumns(db) {
+		_ = db.AddError(enums.ErrorMissingTenantScope)
+	}
+
+	return scope, ok
+}
+
+func (c *callbacks) hasTenantColumns(db *gorm.DB) bool {
+	for _, column := range enums.Columns {
+		if c.hasColumn(db, column) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasColumn looks up the column in the schema of the statement, or in the table itself when there is no schema,
+// like in deletes made only with the table name, caching the result by table.
+func (c *callbacks) hasColumn(db *gorm.DB, column string) bool {
+	if db.Statement.Schema != nil {
+		return db.Statement.Schema.LookUpField(column) != nil
+	}
+
+	if db.Statement.Table == "" {
+		return false
+	}
+
+	key := db.Statement.Table + "." + column
+	if hasColumn, ok := c.columns.Load(key); ok {
+		return hasColumn.(bool)
+	}
+
+	hasColumn := db.Session(&gorm.Session{NewDB: true, Context: context.Background()}).Migrator().
+		HasColumn(db.Statement.Table, column)
+	c.columns.Store(key, hasColumn)
+
+	return hasColumn
+}
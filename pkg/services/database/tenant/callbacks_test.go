@@ -0,0 +1,154 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant/enums"
+)
+
+type testVulnerability struct {
+	ID           int       `gorm:"Column:id;primaryKey"`
+	AnalysisID   int       `gorm:"Column:analysis_id"`
+	WorkspaceID  uuid.UUID `gorm:"Column:workspace_id"`
+	RepositoryID uuid.UUID `gorm:"Column:repository_id"`
+}
+
+type testAnalysis struct {
+	ID              int                 `gorm:"Column:id;primaryKey"`
+	WorkspaceID     uuid.UUID           `gorm:"Column:workspace_id"`
+	RepositoryID    uuid.UUID           `gorm:"Column:repository_id"`
+	Vulnerabilities []testVulnerability `gorm:"foreignKey:AnalysisID"`
+}
+
+type testAccount struct {
+	ID   int    `gorm:"Column:id;primaryKey"`
+	Name string `gorm:"Column:name"`
+}
+
+func getTestConnection(t *testing.T) *gorm.DB {
+	connection, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory", uuid.NewString())),
+		&gorm.Config{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, connection.AutoMigrate(&testAnalysis{}, &testVulnerability{}, &testAccount{}))
+	assert.NoError(t, RegisterCallbacks(connection))
+
+	return connection
+}
+
+func createTestAnalysis(t *testing.T, connection *gorm.DB, id int, workspaceID, repositoryID uuid.UUID) {
+	assert.NoError(t, connection.Create(&testAnalysis{ID: id, WorkspaceID: workspaceID, RepositoryID: repositoryID,
+		Vulnerabilities: []testVulnerability{{ID: id, WorkspaceID: workspaceID, RepositoryID: repositoryID}}}).Error)
+}
+
+func TestSetCreateColumns(t *testing.T) {
+	t.Run("should fill the tenant columns with the scope of the context", func(t *testing.T) {
+		workspaceID, repositoryID := uuid.New(), uuid.New()
+		connection := getTestConnection(t)
+
+		ctx := WithScope(context.Background(), workspaceID, repositoryID)
+		assert.NoError(t, connection.WithContext(ctx).Create(&testAnalysis{ID: 1, WorkspaceID: uuid.New()}).Error)
+
+		result := &testAnalysis{}
+		assert.NoError(t, connection.First(result).Error)
+		assert.Equal(t, workspaceID, result.WorkspaceID)
+		assert.Equal(t, repositoryID, result.RepositoryID)
+	})
+}
+
+func TestAddFilters(t *testing.T) {
+	t.Run("should only read and preload the records of the scope", func(t *testing.T) {
+		workspaceID, repositoryID := uuid.New(), uuid.New()
+		connection := getTestConnection(t)
+		createTestAnalysis(t, connection, 1, workspaceID, repositoryID)
+		createTestAnalysis(t, connection, 2, workspaceID, uuid.New())
+		createTestAnalysis(t, connection, 3, uuid.New(), uuid.New())
+
+		var analysis []testAnalysis
+		assert.NoError(t, connection.WithContext(WithScope(context.Background(), workspaceID, uuid.Nil)).
+			Preload("Vulnerabilities").Order("id").Find(&analysis).Error)
+		assert.Len(t, analysis, 2)
+		assert.Len(t, analysis[1].Vulnerabilities, 1)
+
+		var count int64
+		assert.NoError(t, connection.WithContext(WithScope(context.Background(), workspaceID, repositoryID)).
+			Model(&testAnalysis{}).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should only update and delete the records of the scope", func(t *testing.T) {
+		workspaceID := uuid.New()
+		connection := getTestConnection(t)
+		createTestAnalysis(t, connection, 1, workspaceID, uuid.New())
+		createTestAnalysis(t, connection, 2, uuid.New(), uuid.New())
+
+		scoped := connection.WithContext(WithScope(context.Background(), workspaceID, uuid.Nil))
+
+		result := scoped.Table("test_analyses").Where("id > 0").Update("repository_id", uuid.Nil)
+		assert.NoError(t, result.Error)
+		assert.Equal(t, int64(1), result.RowsAffected)
+
+		result = scoped.Table("test_vulnerabilities").Where("id > 0").Delete(nil)
+		assert.NoError(t, result.Error)
+		assert.Equal(t, int64(1), result.RowsAffected)
+	})
+
+	t.Run("should not filter tables without tenant columns", func(t *testing.T) {
+		connection := getTestConnection(t)
+		assert.NoError(t, connection.Create(&testAccount{ID: 1, Name: "test"}).Error)
+
+		var accounts []testAccount
+		assert.NoError(t, connection.WithContext(WithRequiredScope(WithScope(context.Background(), uuid.New(),
+			uuid.Nil))).Find(&accounts).Error)
+		assert.Len(t, accounts, 1)
+	})
+
+	t.Run("should return error when the scope is required and the context has none", func(t *testing.T) {
+		connection := getTestConnection(t)
+		createTestAnalysis(t, connection, 1, uuid.New(), uuid.New())
+
+		var analysis []testAnalysis
+		err := connection.WithContext(WithRequiredScope(context.Background())).Find(&analysis).Error
+		assert.ErrorIs(t, err, enums.ErrorMissingTenantScope)
+
+		err = connection.WithContext(WithRequiredScope(context.Background())).Table("test_analyses").
+			Where("id > 0").Delete(nil).Error
+		assert.ErrorIs(t, err, enums.ErrorMissingTenantScope)
+
+		var accounts []testAccount
+		assert.NoError(t, connection.WithContext(WithRequiredScope(context.Background())).Find(&accounts).Error)
+	})
+
+	t.Run("should not filter the records of application admins", func(t *testing.T) {
+		connection := getTestConnection(t)
+		createTestAnalysis(t, connection, 1, uuid.New(), uuid.New())
+		createTestAnalysis(t, connection, 2, uuid.New(), uuid.New())
+
+		ctx := WithApplicationAdmin(WithRequiredScope(WithScope(context.Background(), uuid.New(), uuid.Nil)))
+
+		var analysis []testAnalysis
+		assert.NoError(t, connection.WithContext(ctx).Find(&analysis).Error)
+		assert.Len(t, analysis, 2)
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+const (
+	ColumnWorkspaceID  = "workspace_id"
+	ColumnRepositoryID = "repository_id"
+
+	CallbackCreateColumns = "horusec:tenant_create_columns"
+	CallbackQueryFilter   = "horusec:tenant_query_filter"
+	CallbackRowFilter     = "horusec:tenant_row_filter"
+	CallbackUpdateFilter  = "horusec:tenant_update_filter"
+	CallbackDeleteFilter  = "horusec:tenant_delete_filter"
+
+	GormCreate = "gorm:create"
+	GormQuery  = "gorm:query"
+	GormRow    = "gorm:row"
+	GormUpdate = "gorm:update"
+	GormDelete = "gorm:delete"
+)
+
+// Columns are the tenant columns in the order that their filters are added.
+var Columns = []string{ColumnWorkspaceID, ColumnRepositoryID}
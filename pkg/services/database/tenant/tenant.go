@@ -0,0 +1,102 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant/enums"
+)
+
+type (
+	scopeKey            struct{}
+	requiredKey         struct{}
+	applicationAdminKey struct{}
+)
+
+// Scope is the workspace and repository that the reads and writes made with a context are restricted to. A nil id
+// means that the records are not filtered by its column.
+type Scope struct {
+	WorkspaceID  uuid.UUID
+	RepositoryID uuid.UUID
+}
+
+// WithScope returns a copy of the context restricting the reads and writes made with it to the workspace and
+// repository, which is done by the authz middleware using the ids of the url.
+// Usage example: connection.WithContext(tenant.WithScope(ctx, workspaceID, repositoryID)).Read.Find(...)
+func WithScope(ctx context.Context, workspaceID, repositoryID uuid.UUID) context.Context {
+	return context.WithValue(ctx, scopeKey{}, Scope{WorkspaceID: workspaceID, RepositoryID: repositoryID})
+}
+
+// GetScope returns the scope carried by the context and if there is one with at least one of the ids.
+func GetScope(ctx context.Context) (Scope, bool) {
+	if ctx == nil {
+		return Scope{}, false
+	}
+
+	scope, ok := ctx.Value(scopeKey{}).(Scope)
+
+	return scope, ok && !scope.IsEmpty()
+}
+
+// WithRequiredScope returns a copy of the context where the reads and writes of tables with tenant columns fail
+// when the context has no scope, instead of accessing the records of every tenant.
+func WithRequiredScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requiredKey{}, true)
+}
+
+func IsScopeRequired(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	required, _ := ctx.Value(requiredKey{}).(bool)
+
+	return required
+}
+
+// WithApplicationAdmin returns a copy of the context where the reads and writes are not restricted by any scope. It
+// is the explicit escape hatch for operations of the application admin, which are made across all tenants.
+func WithApplicationAdmin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, applicationAdminKey{}, true)
+}
+
+func IsApplicationAdmin(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	isAdmin, _ := ctx.Value(applicationAdminKey{}).(bool)
+
+	return isAdmin
+}
+
+func (s *Scope) IsEmpty() bool {
+	return s.WorkspaceID == uuid.Nil && s.RepositoryID == uuid.Nil
+}
+
+// GetID returns the id of the scope for the tenant column, or uuid.Nil when it is not a tenant column.
+func (s *Scope) GetID(column string) uuid.UUID {
+	switch column {
+	case enums.ColumnWorkspaceID:
+		return s.WorkspaceID
+	case enums.ColumnRepositoryID:
+		return s.RepositoryID
+	default:
+		return uuid.Nil
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant/enums"
+)
+
+func TestWithScope(t *testing.T) {
+	t.Run("should return the scope carried by the context", func(t *testing.T) {
+		workspaceID := uuid.New()
+
+		scope, ok := GetScope(WithScope(context.Background(), workspaceID, uuid.Nil))
+
+		assert.True(t, ok)
+		assert.Equal(t, workspaceID, scope.WorkspaceID)
+		assert.Equal(t, uuid.Nil, scope.RepositoryID)
+	})
+
+	t.Run("should return false when the scope has no ids", func(t *testing.T) {
+		_, ok := GetScope(WithScope(context.Background(), uuid.Nil, uuid.Nil))
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should return false when the context has no scope", func(t *testing.T) {
+		_, ok := GetScope(context.Background())
+		assert.False(t, ok)
+
+		//nolint:staticcheck // testing nil context
+		_, ok = GetScope(nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestWithRequiredScope(t *testing.T) {
+	t.Run("should return true when the scope is required", func(t *testing.T) {
+		assert.True(t, IsScopeRequired(WithRequiredScope(context.Background())))
+		assert.False(t, IsScopeRequired(context.Background()))
+	})
+}
+
+func TestWithApplicationAdmin(t *testing.T) {
+	t.Run("should return true when the context is of an application admin", func(t *testing.T) {
+		assert.True(t, IsApplicationAdmin(WithApplicationAdmin(context.Background())))
+		assert.False(t, IsApplicationAdmin(context.Background()))
+	})
+}
+
+func TestGetID(t *testing.T) {
+	t.Run("should return the id of each tenant column", func(t *testing.T) {
+		scope := &Scope{WorkspaceID: uuid.New(), RepositoryID: uuid.New()}
+
+		assert.Equal(t, scope.WorkspaceID, scope.GetID(enums.ColumnWorkspaceID))
+		assert.Equal(t, scope.RepositoryID, scope.GetID(enums.ColumnRepositoryID))
+		assert.Equal(t, uuid.Nil, scope.GetID("test"))
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/config"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant"
+	tenantEnums "github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant/enums"
+)
+
+type testTenantEntity struct {
+	ID          int       `gorm:"Column:id;primaryKey"`
+	WorkspaceID uuid.UUID `gorm:"Column:workspace_id"`
+}
+
+func TestWithTenant(t *testing.T) {
+	t.Run("should restrict the reads and writes to the scope of the context", func(t *testing.T) {
+		databaseConfig := config.NewDatabaseConfig()
+		databaseConfig.SetURI("file:with_tenant?mode=memory&cache=shared")
+		databaseConfig.SetReadURIs(nil)
+
+		connection, err := NewDatabaseReadAndWrite(databaseConfig)
+		assert.NoError(t, err)
+		assert.NoError(t, connection.Write.Exec("CREATE TABLE test_tenant (id INTEGER PRIMARY KEY, workspace_id TEXT)"))
+
+		workspaceID := uuid.New()
+		scoped := connection.WithTenant(tenant.WithScope(context.Background(), workspaceID, uuid.Nil))
+		other := connection.WithTenant(tenant.WithScope(context.Background(), uuid.New(), uuid.Nil))
+
+		assert.NoError(t, scoped.Write.Create(&testTenantEntity{ID: 1}, "test_tenant").GetError())
+		assert.NoError(t, other.Write.Create(&testTenantEntity{ID: 2}, "test_tenant").GetError())
+
+		var entities []testTenantEntity
+		assert.NoError(t, scoped.Read.Find(&entities, nil, "test_tenant").GetError())
+		assert.Equal(t, []testTenantEntity{{ID: 1, WorkspaceID: workspaceID}}, entities)
+
+		result := connection.WithTenant(context.Background()).Read.Find(&entities, nil, "test_tenant")
+		assert.ErrorIs(t, result.GetError(), tenantEnums.ErrorMissingTenantScope)
+
+		entities = nil
+		admin := connection.WithTenant(tenant.WithApplicationAdmin(context.Background()))
+		assert.NoError(t, admin.Read.Find(&entities, nil, "test_tenant").GetError())
+		assert.Len(t, entities, 2)
+	})
+
+	t.Run("should keep the scope of the context on transactions", func(t *testing.T) {
+		databaseConfig := config.NewDatabaseConfig()
+		databaseConfig.SetURI("file:with_tenant_transaction?mode=memory&cache=shared")
+		databaseConfig.SetReadURIs(nil)
+
+		connection, err := NewDatabaseReadAndWrite(databaseConfig)
+		assert.NoError(t, err)
+		assert.NoError(t, connection.Write.Exec("CREATE TABLE test_tenant (id INTEGER PRIMARY KEY, workspace_id TEXT)"))
+
+		otherWorkspaceID := uuid.New()
+		other := connection.WithTenant(tenant.WithScope(context.Background(), otherWorkspaceID, uuid.Nil))
+		assert.NoError(t, other.Write.Create(&testTenantEntity{ID: 1}, "test_tenant").GetError())
+
+		scoped := connection.WithTenant(tenant.WithScope(context.Background(), uuid.New(), uuid.Nil))
+		err = scoped.Write.WithTransaction(context.Background(), func(tx IDatabaseWrite) error {
+			var entities []testTenantEntity
+			assert.ErrorIs(t, tx.(IDatabaseRead).Find(&entities, nil, "test_tenant").GetError(),
+				enums.ErrorNotFoundRecords)
+
+			result := tx.Delete(map[string]interface{}{"id": 1}, "test_tenant")
+			assert.NoError(t, result.GetError())
+			assert.Equal(t, 0, result.GetRowsAffected())
+
+			return nil
+		})
+		assert.NoError(t, err)
+
+		var entities []testTenantEntity
+		assert.NoError(t, other.Read.Find(&entities, nil, "test_tenant").GetError())
+		assert.Equal(t, []testTenantEntity{{ID: 1, WorkspaceID: otherWorkspaceID}}, entities)
+
+		err = connection.WithTenant(context.Background()).Write.WithTransaction(context.Background(),
+			func(tx IDatabaseWrite) error {
+				return tx.Delete(map[string]interface{}{"id": 1}, "test_tenant").GetError()
+			})
+		assert.ErrorIs(t, err, tenantEnums.ErrorMissingTenantScope)
+	})
+
+	t.Run("should return the same connection when not created by the database service", func(t *testing.T) {
+		connection := &Connection{Read: &Mock{}, Write: &Mock{}}
+
+		assert.Same(t, connection, connection.WithTenant(context.Background()))
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+const (
+	FieldDuration  = "duration_ms"
+	FieldRows      = "rows"
+	FieldCaller    = "caller"
+	FieldSQL       = "sql"
+	FieldRequestID = "request_id"
+	FieldError     = "error"
+
+	LabelTable     = "table"
+	LabelOperation = "operation"
+
+	MetricQueryDurationName = "horusec_database_query_duration_seconds"
+	MetricQueryDurationHelp = "Duration of the database queries by table and operation"
+
+	PluginName     = "horusec:tracing"
+	CallbackStart  = "horusec:tracing_start"
+	CallbackFinish = "horusec:tracing_finish"
+	InstanceStart  = "horusec:tracing_start_time"
+
+	OperationCreate = "create"
+	OperationQuery  = "query"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+	OperationRow    = "row"
+	OperationRaw    = "raw"
+
+	GormSourcePrefix     = "gorm.io/"
+	DatabaseSourcePrefix = "/pkg/services/database/"
+	TestFileSuffix       = "_test.go"
+	MaxCallerDepth       = 20
+)
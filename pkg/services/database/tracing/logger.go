@@ -0,0 +1,144 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tracing/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/logger"
+)
+
+// Logger is a gorm logger writing structured entries through utils/logger with the duration, rows affected, caller
+// and request id of each query. Failed queries are logged as errors, queries slower than the threshold as warnings
+// and the other ones as info when the log mode is enabled, otherwise as debug.
+type Logger struct {
+	logMode       bool
+	slowThreshold time.Duration
+}
+
+// NewLogger creates a new query logger, where a zero slow threshold disables the slow query warnings.
+func NewLogger(logMode bool, slowThreshold time.Duration) gormLogger.Interface {
+	return &Logger{
+		logMode:       logMode,
+		slowThreshold: slowThreshold,
+	}
+}
+
+func (l *Logger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	return &Logger{
+		logMode:       level >= gormLogger.Info,
+		slowThreshold: l.slowThreshold,
+	}
+}
+
+func (l *Logger) Info(_ context.Context, msg string, data ...interface{}) {
+	logger.LogWithFields(logrus.InfoLevel, fmt.Sprintf(msg, data...), map[string]interface{}{
+		enums.FieldCaller: getCaller(),
+	})
+}
+
+func (l *Logger) Warn(_ context.Context, msg string, data ...interface{}) {
+	logger.LogWithFields(logrus.WarnLevel, fmt.Sprintf(msg, data...), map[string]interface{}{
+		enums.FieldCaller: getCaller(),
+	})
+}
+
+func (l *Logger) Error(_ context.Context, msg string, data ...interface{}) {
+	logger.LogWithFields(logrus.ErrorLevel, fmt.Sprintf(msg, data...), map[string]interface{}{
+		enums.FieldCaller: getCaller(),
+	})
+}
+
+func (l *Logger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	duration := time.Since(begin)
+
+	level, message := l.getLevel(duration, err)
+	if !logrus.IsLevelEnabled(level) {
+		return
+	}
+
+	sql, rows := fc()
+	fields := map[string]interface{}{
+		enums.FieldDuration: float64(duration) / float64(time.Millisecond),
+		enums.FieldRows:     rows,
+		enums.FieldCaller:   getCaller(),
+		enums.FieldSQL:      sql,
+	}
+
+	logger.LogWithFields(level, message, l.setContextFields(ctx, fields, level, err))
+}
+
+func (l *Logger) getLevel(duration time.Duration, err error) (logrus.Level, string) {
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return logrus.ErrorLevel, enums.MessageQueryFailed
+	case l.slowThreshold > 0 && duration > l.slowThreshold:
+		return logrus.WarnLevel, enums.MessageSlowQuery
+	case l.logMode:
+		return logrus.InfoLevel, enums.MessageQueryExecuted
+	}
+
+	return logrus.DebugLevel, enums.MessageQueryExecuted
+}
+
+func (l *Logger) setContextFields(ctx context.Context, fields map[string]interface{}, level logrus.Level,
+	err error) map[string]interface{} {
+	if requestID := middleware.GetReqID(ctx); requestID != "" {
+		fields[enums.FieldRequestID] = requestID
+	}
+
+	if level == logrus.ErrorLevel {
+		fields[enums.FieldError] = err.Error()
+	}
+
+	return fields
+}
+
+// getCaller returns the first file and line of the stack outside gorm and the database service, which is where
+// the database service was called. Tests files are always considered callers.
+func getCaller() string {
+	for skip := 2; skip < enums.MaxCallerDepth; skip++ {
+		_, file, line, ok := runtime.Caller(skip)
+		if !ok {
+			break
+		}
+
+		if isCaller(file) {
+			return file + ":" + strconv.Itoa(line)
+		}
+	}
+
+	return ""
+}
+
+func isCaller(file string) bool {
+	if strings.HasSuffix(file, enums.TestFileSuffix) {
+		return true
+	}
+
+	return !strings.Contains(file, enums.GormSourcePrefix) && !strings.Contains(file, enums.DatabaseSourcePrefix)
+}
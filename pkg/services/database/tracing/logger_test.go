@@ -0,0 +1,124 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tracing/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/logger"
+)
+
+func getTestOutput() *bytes.Buffer {
+	output := bytes.NewBufferString("")
+	logger.LogSetOutput(output)
+	logger.SetLogLevel(logrus.InfoLevel.String())
+
+	return output
+}
+
+func getTestQuery() (string, int64) {
+	return "SELECT * FROM analysis", 2
+}
+
+func TestTrace(t *testing.T) {
+	t.Run("should log the query with duration, rows, caller and request id", func(t *testing.T) {
+		output := getTestOutput()
+		ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "request-id")
+
+		NewLogger(true, 0).Trace(ctx, time.Now(), getTestQuery, nil)
+
+		assert.Contains(t, output.String(), enums.MessageQueryExecuted)
+		assert.Contains(t, output.String(), "rows=2")
+		assert.Contains(t, output.String(), "request_id=request-id")
+		assert.Contains(t, output.String(), "logger_test.go")
+		assert.Contains(t, output.String(), enums.FieldDuration)
+	})
+
+	t.Run("should log slow queries as warning even when log mode is disabled", func(t *testing.T) {
+		output := getTestOutput()
+
+		NewLogger(false, time.Millisecond).Trace(context.Background(), time.Now().Add(-time.Second), getTestQuery, nil)
+
+		assert.Contains(t, output.String(), "level=warning")
+		assert.Contains(t, output.String(), enums.MessageSlowQuery)
+	})
+
+	t.Run("should log failed queries as error", func(t *testing.T) {
+		output := getTestOutput()
+
+		NewLogger(false, 0).Trace(context.Background(), time.Now(), getTestQuery, errors.New("test"))
+
+		assert.Contains(t, output.String(), "level=error")
+		assert.Contains(t, output.String(), "error=test")
+	})
+
+	t.Run("should not log when log mode is disabled and query is not slow", func(t *testing.T) {
+		output := getTestOutput()
+
+		NewLogger(false, time.Hour).Trace(context.Background(), time.Now(), getTestQuery, gorm.ErrRecordNotFound)
+
+		assert.Empty(t, output.String())
+	})
+}
+
+func TestLogMode(t *testing.T) {
+	t.Run("should enable the log mode when level is info", func(t *testing.T) {
+		queryLogger := NewLogger(false, time.Second).LogMode(gormLogger.Info)
+
+		assert.Equal(t, &Logger{logMode: true, slowThreshold: time.Second}, queryLogger)
+	})
+
+	t.Run("should disable the log mode when level is error", func(t *testing.T) {
+		queryLogger := NewLogger(true, time.Second).LogMode(gormLogger.Error)
+
+		assert.Equal(t, &Logger{logMode: false, slowThreshold: time.Second}, queryLogger)
+	})
+}
+
+func TestLogMessages(t *testing.T) {
+	t.Run("should log the messages with the caller", func(t *testing.T) {
+		output := getTestOutput()
+		queryLogger := NewLogger(false, 0)
+
+		queryLogger.Info(context.Background(), "info %s", "test")
+		queryLogger.Warn(context.Background(), "warn %s", "test")
+		queryLogger.Error(context.Background(), "error %s", "test")
+
+		assert.Contains(t, output.String(), `msg="info test"`)
+		assert.Contains(t, output.String(), `msg="warn test"`)
+		assert.Contains(t, output.String(), `msg="error test"`)
+		assert.Contains(t, output.String(), "caller=")
+	})
+}
+
+func TestIsCaller(t *testing.T) {
+	t.Run("should ignore gorm and database service files", func(t *testing.T) {
+		assert.False(t, isCaller("/go/pkg/mod/gorm.io/gorm@v1.23.2/finisher_api.go"))
+		assert.False(t, isCaller("/horusec-devkit/pkg/services/database/database.go"))
+		assert.True(t, isCaller("/horusec-devkit/pkg/services/database/database_test.go"))
+		assert.True(t, isCaller("/horusec-api/internal/controllers/analysis.go"))
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tracing/enums"
+)
+
+// Plugin is a gorm plugin exporting the duration of every query to prometheus, as a histogram by table and
+// operation. Usage example: connection.Use(tracing.NewPlugin(prometheus.DefaultRegisterer))
+type Plugin struct {
+	registerer    prometheus.Registerer
+	queryDuration *prometheus.HistogramVec
+}
+
+func NewPlugin(registerer prometheus.Registerer) gorm.Plugin {
+	return &Plugin{
+		registerer: registerer,
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    enums.MetricQueryDurationName,
+			Help:    enums.MetricQueryDurationHelp,
+			Buckets: prometheus.DefBuckets,
+		}, []string{enums.LabelTable, enums.LabelOperation}),
+	}
+}
+
+func (p *Plugin) Name() string {
+	return enums.PluginName
+}
+
+// Initialize registers the histogram, reusing the one already registered by other connections, and the callbacks
+// measuring the queries.
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	if err := p.registerHistogram(); err != nil {
+		return err
+	}
+
+	if err := p.registerStartCallbacks(db); err != nil {
+		return err
+	}
+
+	return p.registerFinishCallbacks(db)
+}
+
+func (p *Plugin) registerHistogram() error {
+	err := p.registerer.Register(p.queryDuration)
+
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		if existing, ok := alreadyRegistered.ExistingCollector.(*prometheus.HistogramVec); ok {
+			p.queryDuration = existing
+
+			return nil
+		}
+	}
+
+	return err
+}
+
+func (p *Plugin) registerStartCallbacks(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	return firstError(
+		callbacks.Create().Before("*").Register(enums.CallbackStart, p.start),
+		callbacks.Query().Before("*").Register(enums.CallbackStart, p.start),
+		callbacks.Update().Before("*").Register(enums.CallbackStart, p.start),
+		callbacks.Delete().Before("*").Register(enums.CallbackStart, p.start),
+		callbacks.Row().Before("*").Register(enums.CallbackStart, p.start),
+		callbacks.Raw().Before("*").Register(enums.CallbackStart, p.start),
+	)
+}
+
+func (p *Plugin) registerFinishCallbacks(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	return firstError(
+		callbacks.Create().After("*").Register(enums.CallbackFinish, p.finish(enums.OperationCreate)),
+		callbacks.Query().After("*").Register(enums.CallbackFinish, p.finish(enums.OperationQuery)),
+		callbacks.Update().After("*").Register(enums.CallbackFinish, p.finish(enums.OperationUpdate)),
+		callbacks.Delete().After("*").Register(enums.CallbackFinish, p.finish(enums.OperationDelete)),
+		callbacks.Row().After("*").Register(enums.CallbackFinish, p.finish(enums.OperationRow)),
+		callbacks.Raw().After("*").Register(enums.CallbackFinish, p.finish(enums.OperationRaw)),
+	)
+}
+
+func (p *Plugin) start(db *gorm.DB) {
+	db.InstanceSet(enums.InstanceStart, time.Now())
+}
+
+func (p *Plugin) finish(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(enums.InstanceStart)
+		if !ok {
+			return
+		}
+
+		if start, isTime := value.(time.Time); isTime {
+			p.queryDuration.WithLabelValues(db.Statement.Table, operation).Observe(time.Since(start).Seconds())
+		}
+	}
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tracing/enums"
+)
+
+func getTestConnection(t *testing.T) *gorm.DB {
+	connection, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return connection
+}
+
+func TestPluginInitialize(t *testing.T) {
+	t.Run("should export the duration of the queries by table and operation", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		connection := getTestConnection(t)
+		assert.NoError(t, connection.Use(NewPlugin(registry)))
+
+		var rows int64
+		assert.NoError(t, connection.Exec("CREATE TABLE analysis (id INTEGER)").Error)
+		assert.NoError(t, connection.Table("analysis").Count(&rows).Error)
+		assert.NoError(t, connection.Table("analysis").Create(map[string]interface{}{"id": 1}).Error)
+
+		count, err := testutil.GatherAndCount(registry, enums.MetricQueryDurationName)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("should reuse the histogram registered by other connection", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+
+		assert.NoError(t, getTestConnection(t).Use(NewPlugin(registry)))
+		assert.NoError(t, getTestConnection(t).Use(NewPlugin(registry)))
+	})
+
+	t.Run("should return error when other collector uses the metric name", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: enums.MetricQueryDurationName,
+			Help: enums.MetricQueryDurationHelp,
+		}, []string{enums.LabelTable, enums.LabelOperation}))
+
+		assert.Error(t, getTestConnection(t).Use(NewPlugin(registry)))
+	})
+
+	t.Run("should return error when plugin already used by the connection", func(t *testing.T) {
+		connection := getTestConnection(t)
+
+		assert.NoError(t, connection.Use(NewPlugin(prometheus.NewRegistry())))
+		assert.Error(t, connection.Use(NewPlugin(prometheus.NewRegistry())))
+	})
+}
+
+func TestPluginName(t *testing.T) {
+	t.Run("should return the plugin name", func(t *testing.T) {
+		assert.Equal(t, enums.PluginName, NewPlugin(prometheus.NewRegistry()).Name())
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/dberror"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/logger"
+)
+
+// sqlStateError is implemented by the postgres driver errors, returning the SQLSTATE code of the error.
+type sqlStateError interface {
+	SQLState() string
+}
+
+// WithTransaction executes the function inside a transaction, committing it when the function succeeds and rolling it
+// back when the function returns an error or panics, in which case the panic is propagated after the rollback.
+// When called with a transaction handle received by another WithTransaction function, a savepoint is used instead,
+// so only the changes of the nested function are rolled back. Transactions aborted by serialization failures or
+// deadlocks are retried following the transaction retry policy of the database config.
+func (d *database) WithTransaction(ctx context.Context, fn func(tx IDatabaseWrite) error) error {
+	if d.isTransaction() {
+		return d.transaction(ctx, fn)
+	}
+
+	return d.retryTransaction(ctx, fn)
+}
+
+// transaction uses the context of the caller merged with the one of the handle, so a transaction started by a handle
+// returned by Connection.WithTenant keeps its tenant scope and audit account. The reads made by the transaction
+// handle use the transaction too, seeing its uncommitted changes.
+func (d *database) transaction(ctx context.Context, fn func(tx IDatabaseWrite) error) error {
+	ctx = d.mergeContext(ctx)
+
+	return dberror.Classify(d.connectionWrite.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&database{connectionWrite: tx, connectionRead: tx, config: d.config, ctx: ctx})
+	}))
+}
+
+func (d *database) retryTransaction(ctx context.Context, fn func(tx IDatabaseWrite) error) error {
+	maxRetries, backoff := d.getRetryPolicy()
+
+	for attempt := 0; ; attempt++ {
+		err := d.transaction(ctx, fn)
+		if err == nil || attempt >= maxRetries || !IsRetryableError(err) {
+			return err
+		}
+
+		logger.LogWarn(fmt.Sprintf(enums.MessageRetryingTransaction, attempt+1, maxRetries), err)
+
+		if err = d.waitBackoff(ctx, backoff<<attempt); err != nil {
+			return err
+		}
+	}
+}
+
+func (d *database) waitBackoff(ctx context.Context, backoff time.Duration) error {
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (d *database) getRetryPolicy() (maxRetries int, backoff time.Duration) {
+	if d.config == nil {
+		return 0, 0
+	}
+
+	return d.config.GetTransactionMaxRetries(), d.config.GetTransactionRetryBackoff()
+}
+
+func (d *database) isTransaction() bool {
+	committer, ok := d.connectionWrite.Statement.ConnPool.(gorm.TxCommitter)
+
+	return ok && committer != nil
+}
+
+// IsRetryableError returns true when the error was caused by a serialization failure or a deadlock, which means that
+// the whole transaction can be executed again.
+func IsRetryableError(err error) bool {
+	var stateError sqlStateError
+	if !errors.As(err, &stateError) {
+		return false
+	}
+
+	return stateError.SQLState() == enums.SQLStateSerializationFailure ||
+		stateError.SQLState() == enums.SQLStateDeadlockDetected
+}
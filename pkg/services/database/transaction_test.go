@@ -0,0 +1,214 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/config"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/enums"
+)
+
+type testStateError struct {
+	code string
+}
+
+func (e *testStateError) Error() string {
+	return "sql state " + e.code
+}
+
+func (e *testStateError) SQLState() string {
+	return e.code
+}
+
+func newTransactionTestDatabase(t *testing.T) (*database, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	databaseConfig := config.NewDatabaseConfig()
+	databaseConfig.SetTransactionRetryBackoff(time.Millisecond)
+
+	return &database{
+		config:          databaseConfig,
+		connectionRead:  getMockedConnection(db),
+		connectionWrite: getMockedConnection(db),
+	}, mock
+}
+
+func TestWithTransaction(t *testing.T) {
+	t.Run("should commit transaction when function succeeds", func(t *testing.T) {
+		database, mock := newTransactionTestDatabase(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := database.WithTransaction(context.Background(), func(tx IDatabaseWrite) error {
+			return tx.Create(newTestEntity(), "test").GetError()
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should rollback transaction when function returns error", func(t *testing.T) {
+		database, mock := newTransactionTestDatabase(t)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		err := database.WithTransaction(context.Background(), func(tx IDatabaseWrite) error {
+			return errors.New("test")
+		})
+
+		assert.EqualError(t, err, "test")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should rollback transaction and propagate panic", func(t *testing.T) {
+		database, mock := newTransactionTestDatabase(t)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		assert.Panics(t, func() {
+			_ = database.WithTransaction(context.Background(), func(tx IDatabaseWrite) error {
+				panic("test")
+			})
+		})
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should rollback only nested savepoint when nested function fails", func(t *testing.T) {
+		database, mock := newTransactionTestDatabase(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := database.WithTransaction(context.Background(), func(tx IDatabaseWrite) error {
+			nestedErr := tx.WithTransaction(context.Background(), func(nested IDatabaseWrite) error {
+				return errors.New("test")
+			})
+
+			assert.EqualError(t, nestedErr, "test")
+
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should retry transaction when serialization failure happens", func(t *testing.T) {
+		database, mock := newTransactionTestDatabase(t)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		attempts := 0
+		err := database.WithTransaction(context.Background(), func(tx IDatabaseWrite) error {
+			attempts++
+			if attempts == 1 {
+				return &testStateError{code: enums.SQLStateSerializationFailure}
+			}
+
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return error after reaching max retries", func(t *testing.T) {
+		database, mock := newTransactionTestDatabase(t)
+		database.config.SetTransactionMaxRetries(1)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		attempts := 0
+		err := database.WithTransaction(context.Background(), func(tx IDatabaseWrite) error {
+			attempts++
+
+			return &testStateError{code: enums.SQLStateDeadlockDetected}
+		})
+
+		assert.True(t, IsRetryableError(err))
+		assert.Equal(t, 2, attempts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not retry when context is canceled", func(t *testing.T) {
+		database, mock := newTransactionTestDatabase(t)
+		database.config.SetTransactionRetryBackoff(time.Minute)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		err := database.WithTransaction(ctx, func(tx IDatabaseWrite) error {
+			cancel()
+
+			return &testStateError{code: enums.SQLStateSerializationFailure}
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("should not retry when database has no config", func(t *testing.T) {
+		database, mock := newTransactionTestDatabase(t)
+		database.config = nil
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		attempts := 0
+		_ = database.WithTransaction(context.Background(), func(tx IDatabaseWrite) error {
+			attempts++
+
+			return &testStateError{code: enums.SQLStateSerializationFailure}
+		})
+
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestIsRetryableError(t *testing.T) {
+	t.Run("should return true for serialization failure and deadlock", func(t *testing.T) {
+		assert.True(t, IsRetryableError(&testStateError{code: enums.SQLStateSerializationFailure}))
+		assert.True(t, IsRetryableError(&testStateError{code: enums.SQLStateDeadlockDetected}))
+	})
+
+	t.Run("should return false for other errors", func(t *testing.T) {
+		assert.False(t, IsRetryableError(&testStateError{code: "23505"}))
+		assert.False(t, IsRetryableError(errors.New("test")))
+		assert.False(t, IsRetryableError(nil))
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validatable is implemented by the entities validated before being written by Create, Update and CreateOrUpdate,
+// like analysis.Analysis and vulnerability.Vulnerability. When the validation fails nothing is written and the
+// response error is the one returned by Validate, usually a validation.Errors with the errors of all invalid fields.
+// Update only considers the errors of the non-zero fields, since they are the only ones written by it.
+type Validatable interface {
+	Validate() error
+}
+
+// validate validates the entity pointer when it is a Validatable, or each element of a slice of them, where the
+// errors of the elements are aggregated by their index.
+func validate(entityPointer interface{}) error {
+	if entity, ok := entityPointer.(Validatable); ok {
+		return entity.Validate()
+	}
+
+	entities := reflect.Indirect(reflect.ValueOf(entityPointer))
+	if entities.Kind() != reflect.Slice && entities.Kind() != reflect.Array {
+		return nil
+	}
+
+	errs := validation.Errors{}
+
+	for index := 0; index < entities.Len(); index++ {
+		if err := validateElement(entities.Index(index)); err != nil {
+			errs[strconv.Itoa(index)] = err
+		}
+	}
+
+	return errs.Filter()
+}
+
+func validateElement(element reflect.Value) error {
+	if element.Kind() != reflect.Ptr && element.CanAddr() {
+		element = element.Addr()
+	}
+
+	if entity, ok := element.Interface().(Validatable); ok {
+		return entity.Validate()
+	}
+
+	return nil
+}
+
+// validatePartial validates the entity like validate, but ignoring the errors of the zero fields, which are not
+// written by the gorm Updates, so partial entities like &vulnerability.Vulnerability{Type: RiskAccepted} are valid.
+// The errors are matched to the fields by their json names, as done by the ozzo-validation.
+func validatePartial(entityPointer interface{}) error {
+	err := validate(entityPointer)
+
+	var errs validation.Errors
+
+	entity := reflect.Indirect(reflect.ValueOf(entityPointer))
+	if !errors.As(err, &errs) || entity.Kind() != reflect.Struct {
+		return err
+	}
+
+	for index := 0; index < entity.NumField(); index++ {
+		if entity.Field(index).IsZero() {
+			delete(errs, getErrorName(entity.Type().Field(index)))
+		}
+	}
+
+	return errs.Filter()
+}
+
+func getErrorName(field reflect.StructField) string {
+	tag := field.Tag.Get(validation.ErrorTag)
+	if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+		return name
+	}
+
+	return field.Name
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+)
+
+type testValidatableEntity struct {
+	Name string
+	Code string `json:"code,omitempty"`
+}
+
+func (e *testValidatableEntity) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.Name, validation.Required),
+		validation.Field(&e.Code, validation.Required, validation.Length(2, 2)),
+	)
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("should return the errors of the entity", func(t *testing.T) {
+		var validationErrors validation.Errors
+
+		assert.ErrorAs(t, validate(&testValidatableEntity{}), &validationErrors)
+		assert.Contains(t, validationErrors, "Name")
+		assert.NoError(t, validate(&testValidatableEntity{Name: "test", Code: "ok"}))
+	})
+
+	t.Run("should aggregate the errors of the slice elements by index", func(t *testing.T) {
+		var validationErrors validation.Errors
+
+		assert.ErrorAs(t, validate(&[]testValidatableEntity{{Name: "test", Code: "ok"}, {}}), &validationErrors)
+		assert.Len(t, validationErrors, 1)
+		assert.Contains(t, validationErrors, "1")
+
+		assert.ErrorAs(t, validate([]*testValidatableEntity{{}, {Name: "test", Code: "ok"}}), &validationErrors)
+		assert.Contains(t, validationErrors, "0")
+	})
+
+	t.Run("should return no error when the entity is not validatable", func(t *testing.T) {
+		assert.NoError(t, validate(&testCursorEntity{}))
+		assert.NoError(t, validate(&[]testCursorEntity{{}}))
+		assert.NoError(t, validate(map[string]interface{}{"name": ""}))
+	})
+}
+
+func TestValidatePartial(t *testing.T) {
+	t.Run("should ignore the errors of the zero fields", func(t *testing.T) {
+		assert.NoError(t, validatePartial(&testValidatableEntity{}))
+		assert.NoError(t, validatePartial(&testValidatableEntity{Code: "ok"}))
+		assert.NoError(t, validatePartial(&vulnerability.Vulnerability{Type: vulnerabilityEnums.RiskAccepted}))
+	})
+
+	t.Run("should return the errors of the non-zero fields by their json names", func(t *testing.T) {
+		var validationErrors validation.Errors
+
+		assert.ErrorAs(t, validatePartial(&testValidatableEntity{Code: "invalid"}), &validationErrors)
+		assert.Len(t, validationErrors, 1)
+		assert.Contains(t, validationErrors, "code")
+
+		assert.ErrorAs(t, validatePartial(&vulnerability.Vulnerability{Type: "test"}), &validationErrors)
+		assert.Len(t, validationErrors, 1)
+		assert.Contains(t, validationErrors, "type")
+	})
+
+	t.Run("should return no error when the entity is not validatable", func(t *testing.T) {
+		assert.NoError(t, validatePartial(map[string]interface{}{"name": ""}))
+	})
+}
+
+func TestWriteValidation(t *testing.T) {
+	t.Run("should not write invalid entities", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+
+		database := &database{connectionWrite: getMockedConnection(db)}
+
+		var validationErrors validation.Errors
+		assert.True(t, errors.As(database.Create(&testValidatableEntity{}, "test").GetError(), &validationErrors))
+		assert.True(t, errors.As(database.Update(&testValidatableEntity{Code: "invalid"}, nil, "test").GetError(),
+			&validationErrors))
+		assert.True(t, errors.As(database.CreateOrUpdate(&testValidatableEntity{}, nil, "test").GetError(),
+			&validationErrors))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -14,16 +14,27 @@
 
 package database
 
-import "github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
+import (
+	"context"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/response"
+)
 
 type IDatabaseWrite interface {
 	StartTransaction() IDatabaseWrite
 	RollbackTransaction() response.IResponse
 	CommitTransaction() response.IResponse
+	WithTransaction(ctx context.Context, fn func(tx IDatabaseWrite) error) error
+	WithContext(ctx context.Context) IDatabaseWrite
 	IsAvailable() bool
 	Create(entityPointer interface{}, table string) response.IResponse
 	CreateOrUpdate(entityPointer interface{}, where map[string]interface{}, table string) response.IResponse
 	Update(entityPointer interface{}, where map[string]interface{}, table string) response.IResponse
 	Delete(where map[string]interface{}, table string) response.IResponse
+	SoftDelete(where map[string]interface{}, table string) response.IResponse
+	CreateInBatches(entitiesPointer interface{}, batchSize int, table string) response.IResponse
+	BulkUpsert(entitiesPointer interface{}, conflictColumns, updateColumns []string, batchSize int,
+		table string) response.IResponse
+	CopyFrom(entitiesPointer interface{}, table string) response.IResponse
 	Exec(rawQuery string, values ...interface{}) error
 }
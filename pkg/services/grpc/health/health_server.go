@@ -19,21 +19,28 @@ import (
 
 	"google.golang.org/grpc/health/grpc_health_v1"
 
+	healthEntities "github.com/Fotkurz/horusec-devkit/pkg/entities/health"
 	"github.com/Fotkurz/horusec-devkit/pkg/utils/logger"
 )
 
-type CheckServer struct{}
+type CheckServer struct {
+	checkers []healthEntities.IChecker
+}
 
-func NewHealthCheckGrpcServer() *CheckServer {
-	return &CheckServer{}
+// NewHealthCheckGrpcServer creates the grpc health server, answering not serving when the report of the checkers is
+// down. Without checkers it always answers serving.
+func NewHealthCheckGrpcServer(checkers ...healthEntities.IChecker) *CheckServer {
+	return &CheckServer{
+		checkers: checkers,
+	}
 }
 
-func (c *CheckServer) Check(context.Context,
-	*grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+func (c *CheckServer) Check(ctx context.Context,
+	_ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
 	logger.LogInfo("sending the grpc check server request for health check")
 
 	return &grpc_health_v1.HealthCheckResponse{
-		Status: grpc_health_v1.HealthCheckResponse_SERVING,
+		Status: c.getServingStatus(ctx),
 	}, nil
 }
 
@@ -41,6 +48,18 @@ func (c *CheckServer) Watch(_ *grpc_health_v1.HealthCheckRequest, server grpc_he
 	logger.LogInfo("sending the grpc watch request for health check")
 
 	return server.Send(&grpc_health_v1.HealthCheckResponse{
-		Status: grpc_health_v1.HealthCheckResponse_SERVING,
+		Status: c.getServingStatus(server.Context()),
 	})
 }
+
+func (c *CheckServer) getServingStatus(ctx context.Context) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !healthEntities.CheckAll(ctx, c.checkers...).IsAvailable() {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
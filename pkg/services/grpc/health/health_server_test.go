@@ -15,11 +15,24 @@
 package health
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	healthEntities "github.com/Fotkurz/horusec-devkit/pkg/entities/health"
+	healthEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/health"
 )
 
+type testChecker struct {
+	status healthEnums.Status
+}
+
+func (c *testChecker) CheckHealth(_ context.Context) *healthEntities.Report {
+	return healthEntities.NewReport(healthEntities.NewCheck("test", c.status))
+}
+
 func TestNewHealthCheckGrpcServer(t *testing.T) {
 	t.Run("should success create a new service", func(t *testing.T) {
 		assert.NotNil(t, NewHealthCheckGrpcServer())
@@ -34,6 +47,26 @@ func TestCheck(t *testing.T) {
 
 		assert.NotNil(t, response)
 		assert.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, response.Status)
+	})
+
+	t.Run("should return serving when the report is degraded", func(t *testing.T) {
+		service := NewHealthCheckGrpcServer(&testChecker{status: healthEnums.Degraded})
+
+		response, err := service.Check(context.Background(), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, response.Status)
+	})
+
+	t.Run("should return not serving when the report is down", func(t *testing.T) {
+		service := NewHealthCheckGrpcServer(&testChecker{status: healthEnums.Up},
+			&testChecker{status: healthEnums.Down})
+
+		response, err := service.Check(context.Background(), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, response.Status)
 	})
 }
 
@@ -0,0 +1,75 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+
+	healthEntities "github.com/Fotkurz/horusec-devkit/pkg/entities/health"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/http/health/enums"
+	httpEntities "github.com/Fotkurz/horusec-devkit/pkg/utils/http/entities"
+)
+
+type IHandler interface {
+	Liveness(w http.ResponseWriter, r *http.Request)
+	Readiness(w http.ResponseWriter, r *http.Request)
+	Register(router chi.Router)
+}
+
+type Handler struct {
+	checkers []healthEntities.IChecker
+}
+
+// NewHandler creates the http health handler, where the readiness uses the reports of the checkers, like the
+// database.NewHealthChecker, and the liveness only verifies that the service is able to answer requests.
+func NewHandler(checkers ...healthEntities.IChecker) IHandler {
+	return &Handler{
+		checkers: checkers,
+	}
+}
+
+// Register adds the liveness and readiness routes into the router.
+// Usage example: health.NewHandler(checkers...).Register(router.GetMux())
+func (h *Handler) Register(router chi.Router) {
+	router.Get(enums.LivenessRoute, h.Liveness)
+	router.Get(enums.ReadinessRoute, h.Readiness)
+}
+
+func (h *Handler) Liveness(w http.ResponseWriter, _ *http.Request) {
+	h.writeReport(w, healthEntities.NewReport())
+}
+
+// Readiness returns status ok when the report is up or degraded, otherwise service unavailable, always with the
+// report as content.
+func (h *Handler) Readiness(w http.ResponseWriter, r *http.Request) {
+	h.writeReport(w, healthEntities.CheckAll(r.Context(), h.checkers...))
+}
+
+func (h *Handler) writeReport(w http.ResponseWriter, report *healthEntities.Report) {
+	statusCode := http.StatusOK
+	if !report.IsAvailable() {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	response := &httpEntities.Response{}
+	response.SetResponseData(statusCode, http.StatusText(statusCode), report)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(response)
+}
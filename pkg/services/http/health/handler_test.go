@@ -0,0 +1,103 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+
+	healthEntities "github.com/Fotkurz/horusec-devkit/pkg/entities/health"
+	healthEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/health"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/http/health/enums"
+)
+
+type testChecker struct {
+	status healthEnums.Status
+}
+
+func (c *testChecker) CheckHealth(_ context.Context) *healthEntities.Report {
+	return healthEntities.NewReport(healthEntities.NewCheck("test", c.status))
+}
+
+func getReportStatus(t *testing.T, w *httptest.ResponseRecorder) healthEnums.Status {
+	response := struct {
+		Content healthEntities.Report `json:"content"`
+	}{}
+
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+
+	return response.Content.Status
+}
+
+func TestNewHandler(t *testing.T) {
+	t.Run("should success create a new handler", func(t *testing.T) {
+		assert.NotNil(t, NewHandler())
+	})
+}
+
+func TestLiveness(t *testing.T) {
+	t.Run("should return status ok even when the checkers are down", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		NewHandler(&testChecker{status: healthEnums.Down}).Liveness(w,
+			httptest.NewRequest(http.MethodGet, enums.LivenessRoute, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, healthEnums.Up, getReportStatus(t, w))
+	})
+}
+
+func TestReadiness(t *testing.T) {
+	t.Run("should return status ok when the report is degraded", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		NewHandler(&testChecker{status: healthEnums.Up}, &testChecker{status: healthEnums.Degraded}).Readiness(w,
+			httptest.NewRequest(http.MethodGet, enums.ReadinessRoute, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, healthEnums.Degraded, getReportStatus(t, w))
+	})
+
+	t.Run("should return service unavailable when the report is down", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		NewHandler(&testChecker{status: healthEnums.Down}).Readiness(w,
+			httptest.NewRequest(http.MethodGet, enums.ReadinessRoute, nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, healthEnums.Down, getReportStatus(t, w))
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("should success register the health routes", func(t *testing.T) {
+		router := chi.NewRouter()
+		NewHandler(&testChecker{status: healthEnums.Down}).Register(router)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, enums.ReadinessRoute, nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, enums.LivenessRoute, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
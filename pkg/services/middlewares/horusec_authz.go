@@ -24,6 +24,7 @@ import (
 	"google.golang.org/grpc"
 
 	authEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/auth"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant"
 	"github.com/Fotkurz/horusec-devkit/pkg/services/grpc/auth/proto"
 	"github.com/Fotkurz/horusec-devkit/pkg/services/middlewares/enums"
 	httpUtil "github.com/Fotkurz/horusec-devkit/pkg/utils/http"
@@ -61,16 +62,23 @@ func (a *AuthzMiddleware) IsApplicationAdmin(handler http.Handler) http.Handler
 		}
 
 		if authConfig.EnableApplicationAdmin {
-			response, err := a.grpcClient.IsAuthorized(a.ctx, a.setAuthorizedData(r, authEnums.ApplicationAdmin))
-			if a.checkIsAuthorizedResponse(err, response, w, r, authEnums.ApplicationAdmin) != nil {
+			if a.checkIsApplicationAdmin(w, r) != nil {
 				return
 			}
+
+			r = r.WithContext(tenant.WithApplicationAdmin(r.Context()))
 		}
 
-		handler.ServeHTTP(w, r)
+		handler.ServeHTTP(w, a.withTenantScope(r))
 	})
 }
 
+func (a *AuthzMiddleware) checkIsApplicationAdmin(w http.ResponseWriter, r *http.Request) error {
+	response, err := a.grpcClient.IsAuthorized(a.ctx, a.setAuthorizedData(r, authEnums.ApplicationAdmin))
+
+	return a.checkIsAuthorizedResponse(err, response, w, r, authEnums.ApplicationAdmin)
+}
+
 func (a *AuthzMiddleware) IsWorkspaceMember(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response, err := a.grpcClient.IsAuthorized(a.ctx, a.setAuthorizedData(r, authEnums.WorkspaceMember))
@@ -78,7 +86,7 @@ func (a *AuthzMiddleware) IsWorkspaceMember(handler http.Handler) http.Handler {
 			return
 		}
 
-		handler.ServeHTTP(w, r)
+		handler.ServeHTTP(w, a.withTenantScope(r))
 	})
 }
 
@@ -89,7 +97,7 @@ func (a *AuthzMiddleware) IsWorkspaceAdmin(handler http.Handler) http.Handler {
 			return
 		}
 
-		handler.ServeHTTP(w, r)
+		handler.ServeHTTP(w, a.withTenantScope(r))
 	})
 }
 
@@ -100,7 +108,7 @@ func (a *AuthzMiddleware) IsRepositoryMember(handler http.Handler) http.Handler
 			return
 		}
 
-		handler.ServeHTTP(w, r)
+		handler.ServeHTTP(w, a.withTenantScope(r))
 	})
 }
 
@@ -111,7 +119,7 @@ func (a *AuthzMiddleware) IsRepositorySupervisor(handler http.Handler) http.Hand
 			return
 		}
 
-		handler.ServeHTTP(w, r)
+		handler.ServeHTTP(w, a.withTenantScope(r))
 	})
 }
 
@@ -122,7 +130,7 @@ func (a *AuthzMiddleware) IsRepositoryAdmin(handler http.Handler) http.Handler {
 			return
 		}
 
-		handler.ServeHTTP(w, r)
+		handler.ServeHTTP(w, a.withTenantScope(r))
 	})
 }
 
@@ -136,6 +144,15 @@ func (a *AuthzMiddleware) setAuthorizedData(r *http.Request,
 	}
 }
 
+// withTenantScope returns the request with the workspace and repository of the url carried by its context, which
+// restricts the tenant scoped database connections, see database.Connection.WithTenant.
+func (a *AuthzMiddleware) withTenantScope(r *http.Request) *http.Request {
+	workspaceID, _ := uuid.Parse(chi.URLParam(r, enums.WorkspaceID))
+	repositoryID, _ := uuid.Parse(chi.URLParam(r, enums.RepositoryID))
+
+	return r.WithContext(tenant.WithScope(r.Context(), workspaceID, repositoryID))
+}
+
 func (a *AuthzMiddleware) checkIsAuthorizedResponse(err error, response *proto.IsAuthorizedResponse,
 	w http.ResponseWriter, r *http.Request, isAuthorizedType authEnums.AuthorizationType) error {
 	if err != nil {
@@ -20,10 +20,12 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/go-chi/chi"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/tenant"
 	"github.com/Fotkurz/horusec-devkit/pkg/services/grpc/auth/proto"
 	"github.com/Fotkurz/horusec-devkit/pkg/utils/jwt"
 	"github.com/Fotkurz/horusec-devkit/pkg/utils/jwt/entities"
@@ -73,6 +75,33 @@ func TestIsWorkspaceMember(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("should set the tenant scope with the workspace of the url", func(t *testing.T) {
+		grpcMock := &proto.Mock{}
+
+		grpcMock.On("IsAuthorized").Return(&proto.IsAuthorizedResponse{IsAuthorized: true}, nil)
+
+		middleware := AuthzMiddleware{
+			grpcClient: grpcMock,
+		}
+
+		workspaceID := uuid.New()
+		var scope tenant.Scope
+
+		router := chi.NewRouter()
+		router.With(middleware.IsWorkspaceMember).Get("/{workspaceID}", func(_ http.ResponseWriter, r *http.Request) {
+			scope, _ = tenant.GetScope(r.Context())
+		})
+
+		req, _ := http.NewRequest("GET", "http://test/"+workspaceID.String(), nil)
+
+		req.Header.Add("X-Horusec-Authorization", createValidToken())
+
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(t, workspaceID, scope.WorkspaceID)
+		assert.Equal(t, uuid.Nil, scope.RepositoryID)
+	})
+
 	t.Run("should return 500 when failed to verify request", func(t *testing.T) {
 		grpcMock := &proto.Mock{}
 
@@ -530,6 +559,31 @@ func TestIsApplicationAdmin(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("should set the application admin into the context", func(t *testing.T) {
+		grpcMock := &proto.Mock{}
+
+		grpcMock.On("IsAuthorized").Return(&proto.IsAuthorizedResponse{IsAuthorized: true}, nil)
+		grpcMock.On("GetAuthConfig").Return(&proto.
+			GetAuthConfigResponse{AuthType: "test", EnableApplicationAdmin: true}, nil)
+
+		middleware := AuthzMiddleware{
+			grpcClient: grpcMock,
+		}
+
+		isApplicationAdmin := false
+		handler := middleware.IsApplicationAdmin(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			isApplicationAdmin = tenant.IsApplicationAdmin(r.Context())
+		}))
+
+		req, _ := http.NewRequest("GET", "http://test", nil)
+
+		req.Header.Add("X-Horusec-Authorization", createValidToken())
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.True(t, isApplicationAdmin)
+	})
+
 	t.Run("should return 500 when failed to verify request", func(t *testing.T) {
 		grpcMock := &proto.Mock{}
 
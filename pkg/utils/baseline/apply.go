@@ -0,0 +1,91 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baseline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/baseline/enums"
+)
+
+// Apply sets the type of the analysis vulnerabilities matched by the entries in the scope of the analysis. Expired
+// entries are not applied and a warning is added to the analysis for each of them, as well as for the entries
+// matched by a deprecated hash, which should be updated to the current vulnerability hash.
+// Usage example: baseline.Apply(analysis)
+func (b *Baseline) Apply(entity *analysis.Analysis) {
+	b.apply(entity, time.Now())
+}
+
+func (b *Baseline) apply(entity *analysis.Analysis, now time.Time) {
+	for _, entry := range b.Entries {
+		if !entry.IsInScope(entity) {
+			continue
+		}
+
+		if entry.IsExpired(now) {
+			entity.AddWarning(fmt.Sprintf(enums.MessageExpiredEntry, entry.Hash,
+				entry.ExpiresAt.Format(enums.ExpirationDateLayout)))
+
+			continue
+		}
+
+		entry.apply(entity)
+	}
+}
+
+func (e *Entry) apply(entity *analysis.Analysis) {
+	for index := range entity.AnalysisVulnerabilities {
+		vuln := &entity.AnalysisVulnerabilities[index].Vulnerability
+
+		if vuln.VulnHash == e.Hash {
+			vuln.Type = e.Type
+
+			continue
+		}
+
+		if isDeprecatedHash(vuln, e.Hash) {
+			vuln.Type = e.Type
+			entity.AddWarning(fmt.Sprintf(enums.MessageDeprecatedHash, e.Hash, vuln.VulnHash))
+		}
+	}
+}
+
+// IsExpired returns true when the entry has an expiration date that is not after the time.
+func (e *Entry) IsExpired(now time.Time) bool {
+	return e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)
+}
+
+// IsInScope returns true when the entry has no scope or its workspace and repository match the analysis ones.
+func (e *Entry) IsInScope(entity *analysis.Analysis) bool {
+	if e.Scope == nil {
+		return true
+	}
+
+	return (e.Scope.Workspace == "" || e.Scope.Workspace == entity.WorkspaceName) &&
+		(e.Scope.Repository == "" || e.Scope.Repository == entity.RepositoryName)
+}
+
+func isDeprecatedHash(vuln *vulnerability.Vulnerability, hash string) bool {
+	for _, deprecatedHash := range vuln.DeprecatedHashes {
+		if deprecatedHash == hash {
+			return true
+		}
+	}
+
+	return false
+}
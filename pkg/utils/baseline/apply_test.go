@@ -0,0 +1,90 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baseline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	entitiesVulnerability "github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+)
+
+func getTestAnalysis() *analysis.Analysis {
+	return &analysis.Analysis{RepositoryName: "my-project", AnalysisVulnerabilities: []analysis.AnalysisVulnerabilities{
+		{Vulnerability: entitiesVulnerability.Vulnerability{VulnHash: "hash1", Type: vulnerability.Vulnerability}},
+		{Vulnerability: entitiesVulnerability.Vulnerability{VulnHash: "current", DeprecatedHashes: []string{"hash2"},
+			Type: vulnerability.Vulnerability}},
+		{Vulnerability: entitiesVulnerability.Vulnerability{VulnHash: "hash3", Type: vulnerability.Vulnerability}},
+	}}
+}
+
+func TestApply(t *testing.T) {
+	baseline, err := ParseBaseline([]byte(testBaselineYAML))
+	assert.NoError(t, err)
+
+	t.Run("should set the type of the matched vulnerabilities", func(t *testing.T) {
+		entity := getTestAnalysis()
+
+		baseline.apply(entity, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, vulnerability.FalsePositive, entity.AnalysisVulnerabilities[0].Vulnerability.Type)
+		assert.Equal(t, vulnerability.RiskAccepted, entity.AnalysisVulnerabilities[1].Vulnerability.Type)
+		assert.Equal(t, vulnerability.Vulnerability, entity.AnalysisVulnerabilities[2].Vulnerability.Type)
+		assert.Equal(t, []string{"{BASELINE} entry hash hash2 is deprecated, replace it with the current " +
+			"vulnerability hash current"}, entity.Warnings)
+	})
+
+	t.Run("should not apply and warn the expired entries", func(t *testing.T) {
+		entity := getTestAnalysis()
+
+		baseline.apply(entity, time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, vulnerability.FalsePositive, entity.AnalysisVulnerabilities[0].Vulnerability.Type)
+		assert.Equal(t, vulnerability.Vulnerability, entity.AnalysisVulnerabilities[1].Vulnerability.Type)
+		assert.Equal(t, []string{"{BASELINE} entry of the vulnerability hash2 expired at 2022-01-31, its " +
+			"classification was not applied"}, entity.Warnings)
+	})
+
+	t.Run("should ignore the entries out of the scope", func(t *testing.T) {
+		entity := getTestAnalysis()
+		entity.RepositoryName = "other-project"
+
+		baseline.Apply(entity)
+
+		assert.Equal(t, vulnerability.FalsePositive, entity.AnalysisVulnerabilities[0].Vulnerability.Type)
+		assert.Equal(t, vulnerability.Vulnerability, entity.AnalysisVulnerabilities[1].Vulnerability.Type)
+		assert.Empty(t, entity.Warnings)
+	})
+}
+
+func TestIsInScope(t *testing.T) {
+	entity := &analysis.Analysis{WorkspaceName: "my-workspace", RepositoryName: "my-project"}
+
+	t.Run("should return true when scope matches", func(t *testing.T) {
+		assert.True(t, (&Entry{}).IsInScope(entity))
+		assert.True(t, (&Entry{Scope: &Scope{}}).IsInScope(entity))
+		assert.True(t, (&Entry{Scope: &Scope{Workspace: "my-workspace"}}).IsInScope(entity))
+		assert.True(t, (&Entry{Scope: &Scope{Workspace: "my-workspace", Repository: "my-project"}}).IsInScope(entity))
+	})
+
+	t.Run("should return false when scope does not match", func(t *testing.T) {
+		assert.False(t, (&Entry{Scope: &Scope{Workspace: "other"}}).IsInScope(entity))
+		assert.False(t, (&Entry{Scope: &Scope{Workspace: "my-workspace", Repository: "other"}}).IsInScope(entity))
+	})
+}
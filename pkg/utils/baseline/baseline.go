@@ -0,0 +1,135 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baseline
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/baseline/enums"
+	validationUtils "github.com/Fotkurz/horusec-devkit/pkg/utils/validation"
+)
+
+// Baseline is a portable file with the classification of vulnerabilities as false positives or accepted risks, which
+// can be versioned together with the project source code.
+type Baseline struct {
+	Version int      `json:"version" yaml:"version"`
+	Entries []*Entry `json:"entries" yaml:"entries"`
+}
+
+// Entry classifies the vulnerability with the hash, which could be its current or any of its deprecated hashes. Entries
+// without expiration date never expire, and entries without scope are applied to all workspaces and repositories.
+type Entry struct {
+	Hash      string             `json:"hash" yaml:"hash"`
+	Type      vulnerability.Type `json:"type" yaml:"type"`
+	Reason    string             `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Author    string             `json:"author,omitempty" yaml:"author,omitempty"`
+	ExpiresAt *time.Time         `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	Scope     *Scope             `json:"scope,omitempty" yaml:"scope,omitempty"`
+}
+
+// Scope restricts the entry to the analysis of the workspace and repository names, where an empty name matches any.
+type Scope struct {
+	Workspace  string `json:"workspace,omitempty" yaml:"workspace,omitempty"`
+	Repository string `json:"repository,omitempty" yaml:"repository,omitempty"`
+}
+
+// ParseBaseline parses a baseline written in JSON or YAML, returning error when it is invalid or has an unsupported
+// version.
+// Usage example: baseline, err := baseline.ParseBaseline(data)
+func ParseBaseline(data []byte) (*Baseline, error) {
+	baseline := &Baseline{}
+	if err := yaml.Unmarshal(data, baseline); err != nil {
+		return nil, err
+	}
+
+	if baseline.Version != enums.Version {
+		return nil, enums.ErrorUnsupportedVersion
+	}
+
+	if err := baseline.Validate(); err != nil {
+		return nil, err
+	}
+
+	return baseline, nil
+}
+
+// NewBaseline creates a baseline with the vulnerabilities of the analysis already classified as false positive or
+// risk accepted, allowing to export the classifications done on the platform.
+func NewBaseline(entity *analysis.Analysis) *Baseline {
+	baseline := &Baseline{Version: enums.Version, Entries: []*Entry{}}
+
+	for index := range entity.AnalysisVulnerabilities {
+		vuln := &entity.AnalysisVulnerabilities[index].Vulnerability
+		if vuln.VulnHash != "" && isClassificationType(vuln.Type) {
+			baseline.Entries = append(baseline.Entries, &Entry{Hash: vuln.VulnHash, Type: vuln.Type,
+				Scope: &Scope{Workspace: entity.WorkspaceName, Repository: entity.RepositoryName}})
+		}
+	}
+
+	return baseline
+}
+
+func (b *Baseline) ToJSON() []byte {
+	bytes, _ := json.MarshalIndent(b, "", "  ")
+
+	return bytes
+}
+
+func (b *Baseline) ToYAML() []byte {
+	bytes, _ := yaml.Marshal(b)
+
+	return bytes
+}
+
+// Validate returns the errors of all invalid fields, including the ones of each entry by its index.
+func (b *Baseline) Validate() error {
+	return validation.ValidateStruct(b,
+		validation.Field(&b.Entries, validation.By(b.validateEntries)),
+	)
+}
+
+func (b *Baseline) validateEntries(_ interface{}) error {
+	errs := validation.Errors{}
+
+	for index, entry := range b.Entries {
+		if err := entry.Validate(); err != nil {
+			errs[strconv.Itoa(index)] = err
+		}
+	}
+
+	return errs.Filter()
+}
+
+func (e *Entry) Validate() error {
+	return validation.ValidateStruct(e,
+		validation.Field(&e.Hash, validation.Required),
+		validation.Field(&e.Type, validation.Required, validationUtils.InValuesRule(getClassificationTypes())),
+	)
+}
+
+func getClassificationTypes() []vulnerability.Type {
+	return []vulnerability.Type{vulnerability.FalsePositive, vulnerability.RiskAccepted}
+}
+
+func isClassificationType(vulnType vulnerability.Type) bool {
+	return vulnType == vulnerability.FalsePositive || vulnType == vulnerability.RiskAccepted
+}
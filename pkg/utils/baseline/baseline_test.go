@@ -0,0 +1,112 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baseline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	entitiesVulnerability "github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/baseline/enums"
+)
+
+const testBaselineYAML = `
+version: 1
+entries:
+  - hash: hash1
+    type: False Positive
+    reason: test data
+    author: horusec@zup.com.br
+  - hash: hash2
+    type: Risk Accepted
+    reason: fixed on the next release
+    expiresAt: 2022-01-31
+    scope:
+      repository: my-project
+`
+
+func TestParseBaseline(t *testing.T) {
+	t.Run("should parse a yaml baseline", func(t *testing.T) {
+		baseline, err := ParseBaseline([]byte(testBaselineYAML))
+
+		assert.NoError(t, err)
+		assert.Equal(t, enums.Version, baseline.Version)
+		assert.Equal(t, &Entry{Hash: "hash1", Type: vulnerability.FalsePositive, Reason: "test data",
+			Author: "horusec@zup.com.br"}, baseline.Entries[0])
+		assert.Equal(t, time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC), *baseline.Entries[1].ExpiresAt)
+		assert.Equal(t, &Scope{Repository: "my-project"}, baseline.Entries[1].Scope)
+	})
+
+	t.Run("should parse a json baseline", func(t *testing.T) {
+		baseline, err := ParseBaseline([]byte(`{"version": 1, "entries": [{"hash": "hash1", "type": "Risk Accepted",
+			"expiresAt": "2022-01-31T10:00:00Z"}]}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, vulnerability.RiskAccepted, baseline.Entries[0].Type)
+		assert.Equal(t, time.Date(2022, 1, 31, 10, 0, 0, 0, time.UTC), *baseline.Entries[0].ExpiresAt)
+	})
+
+	t.Run("should return error when unsupported version", func(t *testing.T) {
+		_, err := ParseBaseline([]byte(`{"version": 2, "entries": []}`))
+
+		assert.ErrorIs(t, err, enums.ErrorUnsupportedVersion)
+	})
+
+	t.Run("should return error when invalid entries", func(t *testing.T) {
+		parsed, err := ParseBaseline([]byte(`{"version": 1, "entries": [{"type": "Corrected"}]}`))
+
+		assert.EqualError(t, err, "entries: (0: (hash: cannot be blank; type: must be a valid value.).).")
+		assert.Nil(t, parsed)
+	})
+
+	t.Run("should return error when invalid syntax", func(t *testing.T) {
+		_, err := ParseBaseline([]byte(`entries: [`))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNewBaseline(t *testing.T) {
+	t.Run("should create entries for the classified vulnerabilities", func(t *testing.T) {
+		baseline := NewBaseline(&analysis.Analysis{WorkspaceName: "my-workspace", RepositoryName: "my-project",
+			AnalysisVulnerabilities: []analysis.AnalysisVulnerabilities{
+				{Vulnerability: entitiesVulnerability.Vulnerability{VulnHash: "hash1", Type: vulnerability.FalsePositive}},
+				{Vulnerability: entitiesVulnerability.Vulnerability{VulnHash: "hash2", Type: vulnerability.Vulnerability}},
+				{Vulnerability: entitiesVulnerability.Vulnerability{Type: vulnerability.RiskAccepted}},
+			}})
+
+		assert.Equal(t, []*Entry{{Hash: "hash1", Type: vulnerability.FalsePositive,
+			Scope: &Scope{Workspace: "my-workspace", Repository: "my-project"}}}, baseline.Entries)
+	})
+}
+
+func TestToJSONAndToYAML(t *testing.T) {
+	t.Run("should parse the marshaled baseline", func(t *testing.T) {
+		expected, err := ParseBaseline([]byte(testBaselineYAML))
+		assert.NoError(t, err)
+
+		fromJSON, err := ParseBaseline(expected.ToJSON())
+		assert.NoError(t, err)
+		assert.Equal(t, expected, fromJSON)
+
+		fromYAML, err := ParseBaseline(expected.ToYAML())
+		assert.NoError(t, err)
+		assert.Equal(t, expected, fromYAML)
+	})
+}
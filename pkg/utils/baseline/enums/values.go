@@ -0,0 +1,23 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+const (
+	Version = 1
+
+	MessageExpiredEntry   = "{BASELINE} entry of the vulnerability %s expired at %s, its classification was not applied"
+	MessageDeprecatedHash = "{BASELINE} entry hash %s is deprecated, replace it with the current vulnerability hash %s"
+	ExpirationDateLayout  = "2006-01-02"
+)
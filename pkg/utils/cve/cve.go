@@ -0,0 +1,52 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cve
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cve/enums"
+)
+
+var idRegex = regexp.MustCompile(enums.RegexID)
+
+// ParseIDs returns the CVE ids found in the value in upper case, like CVE-2021-23337, without duplications and
+// keeping their order.
+// Usage example: ids := ParseIDs(strings.Join(vuln.CVEs, " "))
+func ParseIDs(value string) (ids []string) {
+	found := map[string]bool{}
+
+	for _, id := range idRegex.FindAllString(value, -1) {
+		id = strings.ToUpper(id)
+		if !found[id] {
+			found[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// IsID returns true when the value contains a CVE id.
+func IsID(value string) bool {
+	return idRegex.MatchString(value)
+}
+
+// FormatURL returns the detail url of the CVE id on the NVD website.
+func FormatURL(id string) string {
+	return fmt.Sprintf(enums.URLFormat, id)
+}
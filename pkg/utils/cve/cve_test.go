@@ -0,0 +1,46 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cve
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIDs(t *testing.T) {
+	t.Run("should return the ids in upper case without duplications", func(t *testing.T) {
+		ids := ParseIDs("cve-2021-23337 fixed, see CVE-2020-8203 and CVE-2021-23337")
+
+		assert.Equal(t, []string{"CVE-2021-23337", "CVE-2020-8203"}, ids)
+	})
+
+	t.Run("should return empty when there are no ids", func(t *testing.T) {
+		assert.Empty(t, ParseIDs("GHSA-35jh-r3h4-6jhm CVE-21-1"))
+	})
+}
+
+func TestIsID(t *testing.T) {
+	t.Run("should return true only for cve ids", func(t *testing.T) {
+		assert.True(t, IsID("CVE-2021-23337"))
+		assert.False(t, IsID("GHSA-35jh-r3h4-6jhm"))
+	})
+}
+
+func TestFormatURL(t *testing.T) {
+	t.Run("should return the nvd url of the id", func(t *testing.T) {
+		assert.Equal(t, "https://nvd.nist.gov/vuln/detail/CVE-2021-23337", FormatURL("CVE-2021-23337"))
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cvss
+
+import "github.com/Fotkurz/horusec-devkit/pkg/utils/cvss/enums"
+
+// metricDefinition contains the values allowed for a metric and if it is required on the vector.
+type metricDefinition struct {
+	values   []string
+	required bool
+}
+
+func getMetricDefinitions(version string) (map[string]metricDefinition, bool) {
+	definitions := map[string]map[string]metricDefinition{
+		enums.Version30: getV3MetricDefinitions(),
+		enums.Version31: getV3MetricDefinitions(),
+	}
+
+	metrics, ok := definitions[version]
+
+	return metrics, ok
+}
+
+func getV3MetricDefinitions() map[string]metricDefinition {
+	return mergeMetricDefinitions(
+		getBaseMetricDefinitions(map[string][]string{
+			"AV": {"N", "A", "L", "P"}, "AC": {"L", "H"}, "PR": {"N", "L", "H"}, "UI": {"N", "R"}, "S": {"U", "C"},
+			"C": {"H", "L", "N"}, "I": {"H", "L", "N"}, "A": {"H", "L", "N"},
+		}),
+		getOptionalMetricDefinitions(map[string][]string{
+			"E": {"H", "F", "P", "U"}, "RL": {"U", "W", "T", "O"}, "RC": {"C", "R", "U"},
+			"CR": {"H", "M", "L"}, "IR": {"H", "M", "L"}, "AR": {"H", "M", "L"},
+			"MAV": {"N", "A", "L", "P"}, "MAC": {"L", "H"}, "MPR": {"N", "L", "H"}, "MUI": {"N", "R"},
+			"MS": {"U", "C"}, "MC": {"H", "L", "N"}, "MI": {"H", "L", "N"}, "MA": {"H", "L", "N"},
+		}),
+	)
+}
+
+func getBaseMetricDefinitions(metrics map[string][]string) map[string]metricDefinition {
+	definitions := map[string]metricDefinition{}
+	for metric, values := range metrics {
+		definitions[metric] = metricDefinition{values: values, required: true}
+	}
+
+	return definitions
+}
+
+// getOptionalMetricDefinitions returns the definitions of metrics that can be omitted or set as not defined.
+func getOptionalMetricDefinitions(metrics map[string][]string) map[string]metricDefinition {
+	definitions := map[string]metricDefinition{}
+	for metric, values := range metrics {
+		definitions[metric] = metricDefinition{values: append(values, enums.NotDefined)}
+	}
+
+	return definitions
+}
+
+func mergeMetricDefinitions(base, optional map[string]metricDefinition) map[string]metricDefinition {
+	for metric, definition := range optional {
+		base[metric] = definition
+	}
+
+	return base
+}
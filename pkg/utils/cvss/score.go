@@ -0,0 +1,161 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cvss
+
+import (
+	"math"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cvss/enums"
+)
+
+// constants of the CVSS 3.x specification, available at https://www.first.org/cvss/v3.1/specification-document
+const (
+	impactUnchangedFactor = 6.42
+	impactChangedFactor   = 7.52
+	impactChangedOffset   = 0.029
+	impactChangedPower    = 3.25
+	impactChangedBase     = 0.02
+	impactChangedExponent = 15
+	exploitabilityFactor  = 8.22
+	scopeChangedFactor    = 1.08
+
+	severityCritical = 9.0
+	severityHigh     = 7.0
+	severityMedium   = 4.0
+	severityLow      = 0.1
+)
+
+// Scores contains the scores of a CVSS vector, where the temporal score is equal to the base score when the vector
+// has no temporal metrics.
+type Scores struct {
+	Base     float64 `json:"base"`
+	Temporal float64 `json:"temporal"`
+}
+
+// Calculate parses the CVSS 3.0 or 3.1 vector and returns its scores.
+// Usage example: scores, err := cvss.Calculate("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H")
+func Calculate(value string) (*Scores, error) {
+	vector, err := Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return vector.GetScores()
+}
+
+// GetSeverity maps the score to the severity using the qualitative rating scale of the CVSS. Scores of zero, which
+// have no severity on the scale, are mapped to info.
+func GetSeverity(score float64) severities.Severity {
+	switch {
+	case score >= severityCritical:
+		return severities.Critical
+	case score >= severityHigh:
+		return severities.High
+	case score >= severityMedium:
+		return severities.Medium
+	case score >= severityLow:
+		return severities.Low
+	default:
+		return severities.Info
+	}
+}
+
+// GetScores returns the base and temporal scores of the vector.
+func (v *Vector) GetScores() (*Scores, error) {
+	base := v.getBaseScore()
+	temporal := base * v.getWeight("E") * v.getWeight("RL") * v.getWeight("RC")
+
+	return &Scores{Base: base, Temporal: v.roundUp(temporal)}, nil
+}
+
+func (v *Vector) getBaseScore() float64 {
+	impact := v.getImpact()
+	if impact <= 0 {
+		return 0
+	}
+
+	exploitability := exploitabilityFactor * v.getWeight("AV") * v.getWeight("AC") * v.getPrivilegesWeight() *
+		v.getWeight("UI")
+
+	if v.isScopeChanged() {
+		return v.roundUp(math.Min(scopeChangedFactor*(impact+exploitability), enums.MaxScore))
+	}
+
+	return v.roundUp(math.Min(impact+exploitability, enums.MaxScore))
+}
+
+func (v *Vector) getImpact() float64 {
+	iss := 1 - (1-v.getWeight("C"))*(1-v.getWeight("I"))*(1-v.getWeight("A"))
+
+	if v.isScopeChanged() {
+		return impactChangedFactor*(iss-impactChangedOffset) -
+			impactChangedPower*math.Pow(iss-impactChangedBase, impactChangedExponent)
+	}
+
+	return impactUnchangedFactor * iss
+}
+
+// getPrivilegesWeight returns the weight of the privileges required, which is higher when the scope is changed.
+//
+//nolint:gomnd // weights of the cvss specification
+func (v *Vector) getPrivilegesWeight() float64 {
+	if v.isScopeChanged() {
+		return map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}[v.Get("PR")]
+	}
+
+	return v.getWeight("PR")
+}
+
+func (v *Vector) getWeight(metric string) float64 {
+	return getV3Weights()[metric][v.Get(metric)]
+}
+
+func (v *Vector) isScopeChanged() bool {
+	return v.Get("S") == "C"
+}
+
+// roundUp returns the smallest number with one decimal place that is equal or higher than the value, where the 3.1
+// version avoids the floating point errors of the 3.0 version.
+//
+//nolint:gomnd // rounding of the cvss specification
+func (v *Vector) roundUp(value float64) float64 {
+	if v.Version == enums.Version30 {
+		return math.Ceil(value*10) / 10
+	}
+
+	integer := math.Round(value * 100000)
+	if math.Mod(integer, 10000) == 0 {
+		return integer / 100000
+	}
+
+	return (math.Floor(integer/10000) + 1) / 10
+}
+
+//nolint:gomnd // weights of the cvss specification
+func getV3Weights() map[string]map[string]float64 {
+	return map[string]map[string]float64{
+		"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+		"AC": {"L": 0.77, "H": 0.44},
+		"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+		"UI": {"N": 0.85, "R": 0.62},
+		"C":  {"H": 0.56, "L": 0.22, "N": 0},
+		"I":  {"H": 0.56, "L": 0.22, "N": 0},
+		"A":  {"H": 0.56, "L": 0.22, "N": 0},
+		"E":  {enums.NotDefined: 1, "H": 1, "F": 0.97, "P": 0.94, "U": 0.91},
+		"RL": {enums.NotDefined: 1, "U": 1, "W": 0.97, "T": 0.96, "O": 0.95},
+		"RC": {enums.NotDefined: 1, "C": 1, "R": 0.96, "U": 0.92},
+	}
+}
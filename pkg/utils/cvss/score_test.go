@@ -0,0 +1,74 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cvss
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cvss/enums"
+)
+
+func TestCalculate(t *testing.T) {
+	t.Run("should calculate the scores of the cvss 3.x vectors", func(t *testing.T) {
+		testCases := []struct {
+			vector string
+			scores Scores
+		}{
+			{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Scores{Base: 9.8, Temporal: 9.8}},
+			{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", Scores{Base: 10, Temporal: 10}},
+			{"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", Scores{Base: 6.1, Temporal: 6.1}},
+			{"CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:H/A:H", Scores{Base: 7.8, Temporal: 7.8}},
+			{"CVSS:3.1/AV:N/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N", Scores{Base: 2.0, Temporal: 2.0}},
+			{"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:L/I:L/A:N", Scores{Base: 6.4, Temporal: 6.4}},
+			{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", Scores{Base: 0, Temporal: 0}},
+			{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:P/RL:O/RC:C", Scores{Base: 9.8, Temporal: 8.8}},
+			{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:U/RL:W/RC:U", Scores{Base: 9.8, Temporal: 8.0}},
+			{"CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Scores{Base: 9.8, Temporal: 9.8}},
+		}
+
+		for _, testCase := range testCases {
+			scores, err := Calculate(testCase.vector)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.scores, *scores, testCase.vector)
+		}
+	})
+
+	t.Run("should return error when cvss 4.0 vector", func(t *testing.T) {
+		_, err := Calculate("CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N")
+
+		assert.ErrorIs(t, err, enums.ErrorUnsupportedVersion)
+	})
+
+	t.Run("should return error when invalid vector", func(t *testing.T) {
+		_, err := Calculate("invalid")
+
+		assert.ErrorIs(t, err, enums.ErrorInvalidVector)
+	})
+}
+
+func TestGetSeverity(t *testing.T) {
+	t.Run("should map the scores to the severities", func(t *testing.T) {
+		assert.Equal(t, severities.Critical, GetSeverity(9.0))
+		assert.Equal(t, severities.High, GetSeverity(8.9))
+		assert.Equal(t, severities.High, GetSeverity(7.0))
+		assert.Equal(t, severities.Medium, GetSeverity(4.0))
+		assert.Equal(t, severities.Low, GetSeverity(0.1))
+		assert.Equal(t, severities.Info, GetSeverity(0))
+	})
+}
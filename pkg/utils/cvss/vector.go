@@ -0,0 +1,96 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cvss
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cvss/enums"
+)
+
+// Vector is a parsed CVSS vector, like "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H".
+type Vector struct {
+	Version string
+	Metrics map[string]string
+}
+
+// Parse parses a CVSS 3.0 or 3.1 vector, returning error when the version is not supported, any metric or value is
+// unknown or duplicated, or any base metric is missing. CVSS 4.0 vectors are not supported, since their scores are
+// calculated from the macro vector lookup table of the specification, so they return enums.ErrorUnsupportedVersion.
+// Usage example: vector, err := cvss.Parse("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H")
+func Parse(value string) (*Vector, error) {
+	parts := strings.Split(strings.TrimSpace(value), enums.MetricSeparator)
+	if !strings.HasPrefix(parts[0], enums.Prefix) {
+		return nil, fmt.Errorf("%w: %s", enums.ErrorInvalidVector, value)
+	}
+
+	vector := &Vector{Version: strings.TrimPrefix(parts[0], enums.Prefix), Metrics: map[string]string{}}
+
+	definitions, ok := getMetricDefinitions(vector.Version)
+	if !ok {
+		return nil, enums.ErrorUnsupportedVersion
+	}
+
+	if err := vector.setMetrics(parts[1:], definitions); err != nil {
+		return nil, err
+	}
+
+	return vector, vector.checkRequiredMetrics(definitions)
+}
+
+// Get returns the value of the metric, or not defined when the metric is not on the vector.
+func (v *Vector) Get(metric string) string {
+	if value, ok := v.Metrics[metric]; ok {
+		return value
+	}
+
+	return enums.NotDefined
+}
+
+func (v *Vector) setMetrics(parts []string, definitions map[string]metricDefinition) error {
+	for _, part := range parts {
+		metric, value, _ := strings.Cut(part, enums.ValueSeparator)
+
+		definition, ok := definitions[metric]
+		if _, duplicated := v.Metrics[metric]; !ok || duplicated || !contains(definition.values, value) {
+			return fmt.Errorf("%w: %s", enums.ErrorInvalidVector, part)
+		}
+
+		v.Metrics[metric] = value
+	}
+
+	return nil
+}
+
+func (v *Vector) checkRequiredMetrics(definitions map[string]metricDefinition) error {
+	for metric, definition := range definitions {
+		if _, ok := v.Metrics[metric]; definition.required && !ok {
+			return fmt.Errorf("%w: missing %s", enums.ErrorInvalidVector, metric)
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cvss
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cvss/enums"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should parse a cvss 3.1 vector", func(t *testing.T) {
+		vector, err := Parse("CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N/E:P")
+
+		assert.NoError(t, err)
+		assert.Equal(t, enums.Version31, vector.Version)
+		assert.Equal(t, "R", vector.Get("UI"))
+		assert.Equal(t, "P", vector.Get("E"))
+		assert.Equal(t, enums.NotDefined, vector.Get("RL"))
+	})
+
+	t.Run("should return error when invalid vectors", func(t *testing.T) {
+		for _, value := range []string{
+			"AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H",
+			"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/A:H",
+			"CVSS:3.1/AV:Z/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/AT:N",
+			"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/",
+		} {
+			_, err := Parse(value)
+
+			assert.ErrorIs(t, err, enums.ErrorInvalidVector, value)
+		}
+	})
+
+	t.Run("should return error when unsupported version", func(t *testing.T) {
+		for _, value := range []string{
+			"CVSS:2.0/AV:N/AC:L/Au:N/C:P/I:P/A:P",
+			"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N",
+		} {
+			_, err := Parse(value)
+
+			assert.ErrorIs(t, err, enums.ErrorUnsupportedVersion, value)
+		}
+	})
+}
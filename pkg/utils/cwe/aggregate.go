@@ -0,0 +1,86 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwe
+
+import (
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cwe/enums"
+)
+
+// GroupByCWE returns the vulnerabilities of the analysis grouped by their CWE ids. A vulnerability with more than
+// one CWE is part of all of their groups, and the ones without CWEs are ignored.
+func GroupByCWE(entity *analysis.Analysis) map[int][]*vulnerability.Vulnerability {
+	groups := map[int][]*vulnerability.Vulnerability{}
+
+	for index := range entity.AnalysisVulnerabilities {
+		vuln := &entity.AnalysisVulnerabilities[index].Vulnerability
+
+		for _, id := range ParseIDs(vuln.CWEs) {
+			groups[id] = append(groups[id], vuln)
+		}
+	}
+
+	return groups
+}
+
+// GroupByOWASP returns the vulnerabilities of the analysis grouped by the OWASP Top 10 categories of their CWEs.
+// A vulnerability is added only once into each category, and the ones without mapped CWEs are ignored.
+func GroupByOWASP(entity *analysis.Analysis) map[enums.OWASPCategory][]*vulnerability.Vulnerability {
+	groups := map[enums.OWASPCategory][]*vulnerability.Vulnerability{}
+
+	for index := range entity.AnalysisVulnerabilities {
+		vuln := &entity.AnalysisVulnerabilities[index].Vulnerability
+
+		for category := range getOWASPCategories(vuln) {
+			groups[category] = append(groups[category], vuln)
+		}
+	}
+
+	return groups
+}
+
+func getOWASPCategories(vuln *vulnerability.Vulnerability) map[enums.OWASPCategory]bool {
+	categories := map[enums.OWASPCategory]bool{}
+
+	for _, id := range ParseIDs(vuln.CWEs) {
+		if category, ok := GetOWASPCategory(id); ok {
+			categories[category] = true
+		}
+	}
+
+	return categories
+}
+
+// CountByCWE returns the number of vulnerabilities of the analysis by CWE id.
+// Usage example: counts := CountByCWE(analysis), counts[79]
+func CountByCWE(entity *analysis.Analysis) map[int]int {
+	return countGroups(GroupByCWE(entity))
+}
+
+// CountByOWASP returns the number of vulnerabilities of the analysis by OWASP Top 10 category.
+// Usage example: counts := CountByOWASP(analysis), counts[enums.Injection]
+func CountByOWASP(entity *analysis.Analysis) map[enums.OWASPCategory]int {
+	return countGroups(GroupByOWASP(entity))
+}
+
+func countGroups[K comparable](groups map[K][]*vulnerability.Vulnerability) map[K]int {
+	counts := make(map[K]int, len(groups))
+	for key, vulnerabilities := range groups {
+		counts[key] = len(vulnerabilities)
+	}
+
+	return counts
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cwe/enums"
+)
+
+func newTestAnalysis() *analysis.Analysis {
+	return &analysis.Analysis{AnalysisVulnerabilities: []analysis.AnalysisVulnerabilities{
+		{Vulnerability: vulnerability.Vulnerability{VulnHash: "1",
+			CWEs: []string{"https://cwe.mitre.org/data/definitions/89.html", "CWE-564"}}},
+		{Vulnerability: vulnerability.Vulnerability{VulnHash: "2", CWEs: []string{"CWE-79", "CWE-918"}}},
+		{Vulnerability: vulnerability.Vulnerability{VulnHash: "3", CWEs: []string{"CWE-787"}}},
+		{Vulnerability: vulnerability.Vulnerability{VulnHash: "4"}},
+	}}
+}
+
+func TestGroupByCWE(t *testing.T) {
+	t.Run("should group the vulnerabilities by cwe", func(t *testing.T) {
+		groups := GroupByCWE(newTestAnalysis())
+
+		assert.Len(t, groups, 5)
+		assert.Equal(t, "1", groups[89][0].VulnHash)
+		assert.Equal(t, "1", groups[564][0].VulnHash)
+		assert.Equal(t, "2", groups[79][0].VulnHash)
+		assert.Equal(t, "3", groups[787][0].VulnHash)
+	})
+}
+
+func TestGroupByOWASP(t *testing.T) {
+	t.Run("should group the vulnerabilities by owasp category once per vulnerability", func(t *testing.T) {
+		groups := GroupByOWASP(newTestAnalysis())
+
+		assert.Len(t, groups, 2)
+		assert.Len(t, groups[enums.Injection], 2)
+		assert.Len(t, groups[enums.ServerSideRequestForgery], 1)
+	})
+}
+
+func TestCountByCWE(t *testing.T) {
+	t.Run("should count the vulnerabilities by cwe", func(t *testing.T) {
+		assert.Equal(t, map[int]int{89: 1, 564: 1, 79: 1, 918: 1, 787: 1}, CountByCWE(newTestAnalysis()))
+	})
+}
+
+func TestCountByOWASP(t *testing.T) {
+	t.Run("should count the vulnerabilities by owasp category", func(t *testing.T) {
+		counts := CountByOWASP(newTestAnalysis())
+
+		assert.Equal(t, map[enums.OWASPCategory]int{enums.Injection: 2, enums.ServerSideRequestForgery: 1}, counts)
+	})
+}
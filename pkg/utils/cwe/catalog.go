@@ -0,0 +1,119 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwe
+
+import (
+	_ "embed" // necessary to embed the catalog
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cwe/enums"
+)
+
+//go:embed catalog.json
+var catalogData []byte
+
+var catalog = mustLoadCatalog(catalogData)
+
+// Weakness represents a CWE entry of the catalog. The parent is the ChildOf relationship of the research view, the
+// OWASP category is the OWASP Top 10 2021 mapping and the top 25 rank is the position in the CWE Top 25 of 2023,
+// both being empty when the weakness is not part of them.
+type Weakness struct {
+	ID        int                 `json:"id"`
+	Name      string              `json:"name"`
+	Parent    int                 `json:"parent,omitempty"`
+	OWASP     enums.OWASPCategory `json:"owasp,omitempty"`
+	Top25Rank int                 `json:"top25Rank,omitempty"`
+}
+
+func mustLoadCatalog(data []byte) map[int]*Weakness {
+	var weaknesses []*Weakness
+	if err := json.Unmarshal(data, &weaknesses); err != nil {
+		panic(fmt.Errorf("%w: %s", enums.ErrorInvalidCatalog, err.Error()))
+	}
+
+	weaknessesByID := make(map[int]*Weakness, len(weaknesses))
+	for _, weakness := range weaknesses {
+		weaknessesByID[weakness.ID] = weakness
+	}
+
+	return weaknessesByID
+}
+
+// Get returns the weakness of the catalog with the id, or false when it's not cataloged.
+func Get(id int) (*Weakness, bool) {
+	weakness, ok := catalog[id]
+
+	return weakness, ok
+}
+
+// GetAll returns all weaknesses of the catalog sorted by id.
+func GetAll() []*Weakness {
+	weaknesses := make([]*Weakness, 0, len(catalog))
+	for _, weakness := range catalog {
+		weaknesses = append(weaknesses, weakness)
+	}
+
+	sort.Slice(weaknesses, func(i, j int) bool {
+		return weaknesses[i].ID < weaknesses[j].ID
+	})
+
+	return weaknesses
+}
+
+// GetTop25 returns the weaknesses of the CWE Top 25 sorted by their rank.
+func GetTop25() []*Weakness {
+	weaknesses := make([]*Weakness, enums.Top25Size)
+	for _, weakness := range catalog {
+		if weakness.IsTop25() {
+			weaknesses[weakness.Top25Rank-1] = weakness
+		}
+	}
+
+	return weaknesses
+}
+
+// GetOWASPCategory returns the OWASP Top 10 category of the weakness. When the weakness isn't directly mapped, the
+// category of the nearest mapped ancestor is used, so a child of CWE-89 as CWE-564 is still considered injection.
+// Usage example: category, ok := GetOWASPCategory(89)
+func GetOWASPCategory(id int) (enums.OWASPCategory, bool) {
+	visited := map[int]bool{}
+
+	for weakness, ok := Get(id); ok && !visited[weakness.ID]; weakness, ok = Get(weakness.Parent) {
+		if weakness.OWASP != "" {
+			return weakness.OWASP, true
+		}
+
+		visited[weakness.ID] = true
+	}
+
+	return "", false
+}
+
+// IsTop25 returns if the weakness is part of the CWE Top 25.
+func (w *Weakness) IsTop25() bool {
+	return w.Top25Rank > 0
+}
+
+// GetName returns the weakness identifier, like CWE-79.
+func (w *Weakness) GetName() string {
+	return FormatID(w.ID)
+}
+
+// GetURL returns the weakness definition url on the mitre website.
+func (w *Weakness) GetURL() string {
+	return FormatURL(w.ID)
+}
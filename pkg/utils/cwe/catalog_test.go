@@ -0,0 +1,131 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cwe/enums"
+)
+
+func TestMustLoadCatalog(t *testing.T) {
+	t.Run("should panic when invalid catalog", func(t *testing.T) {
+		assert.Panics(t, func() {
+			mustLoadCatalog([]byte("invalid"))
+		})
+	})
+
+	t.Run("should have valid parents and categories in the embedded catalog", func(t *testing.T) {
+		categories := enums.OWASPCategories()
+
+		for _, weakness := range GetAll() {
+			assert.NotEmpty(t, weakness.Name)
+
+			if weakness.OWASP != "" {
+				assert.Contains(t, categories, weakness.OWASP)
+			}
+		}
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("should return the cataloged weakness", func(t *testing.T) {
+		weakness, ok := Get(89)
+
+		assert.True(t, ok)
+		assert.Equal(t, 943, weakness.Parent)
+		assert.Equal(t, enums.Injection, weakness.OWASP)
+		assert.Equal(t, 3, weakness.Top25Rank)
+		assert.Contains(t, weakness.Name, "SQL Injection")
+	})
+
+	t.Run("should return false when not cataloged", func(t *testing.T) {
+		_, ok := Get(999999)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("should return all weaknesses sorted by id", func(t *testing.T) {
+		weaknesses := GetAll()
+
+		assert.Len(t, weaknesses, len(catalog))
+		for index := 1; index < len(weaknesses); index++ {
+			assert.Less(t, weaknesses[index-1].ID, weaknesses[index].ID)
+		}
+	})
+}
+
+func TestGetTop25(t *testing.T) {
+	t.Run("should return the top 25 sorted by rank", func(t *testing.T) {
+		weaknesses := GetTop25()
+
+		assert.Len(t, weaknesses, 25)
+		assert.Equal(t, 787, weaknesses[0].ID)
+		assert.Equal(t, 79, weaknesses[1].ID)
+		assert.Equal(t, 276, weaknesses[24].ID)
+
+		for index, weakness := range weaknesses {
+			assert.Equal(t, index+1, weakness.Top25Rank)
+		}
+	})
+}
+
+func TestGetOWASPCategory(t *testing.T) {
+	t.Run("should return the category of a mapped weakness", func(t *testing.T) {
+		category, ok := GetOWASPCategory(918)
+
+		assert.True(t, ok)
+		assert.Equal(t, enums.ServerSideRequestForgery, category)
+	})
+
+	t.Run("should return the category of the nearest mapped ancestor", func(t *testing.T) {
+		category, ok := GetOWASPCategory(732)
+
+		assert.True(t, ok)
+		assert.Equal(t, enums.BrokenAccessControl, category)
+	})
+
+	t.Run("should return false when no ancestor is mapped", func(t *testing.T) {
+		_, ok := GetOWASPCategory(787)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should return false when not cataloged", func(t *testing.T) {
+		_, ok := GetOWASPCategory(999999)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestWeakness(t *testing.T) {
+	t.Run("should return the weakness name, url and top 25 membership", func(t *testing.T) {
+		weakness, _ := Get(79)
+
+		assert.Equal(t, "CWE-79", weakness.GetName())
+		assert.Equal(t, "https://cwe.mitre.org/data/definitions/79.html", weakness.GetURL())
+		assert.True(t, weakness.IsTop25())
+	})
+
+	t.Run("should return false when not in the top 25", func(t *testing.T) {
+		weakness, _ := Get(80)
+
+		assert.False(t, weakness.IsTop25())
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+type OWASPCategory string
+
+const (
+	BrokenAccessControl                     OWASPCategory = "A01:2021-Broken Access Control"
+	CryptographicFailures                   OWASPCategory = "A02:2021-Cryptographic Failures"
+	Injection                               OWASPCategory = "A03:2021-Injection"
+	InsecureDesign                          OWASPCategory = "A04:2021-Insecure Design"
+	SecurityMisconfiguration                OWASPCategory = "A05:2021-Security Misconfiguration"
+	VulnerableAndOutdatedComponents         OWASPCategory = "A06:2021-Vulnerable and Outdated Components"
+	IdentificationAndAuthenticationFailures OWASPCategory = "A07:2021-Identification and Authentication Failures"
+	SoftwareAndDataIntegrityFailures        OWASPCategory = "A08:2021-Software and Data Integrity Failures"
+	SecurityLoggingAndMonitoringFailures    OWASPCategory = "A09:2021-Security Logging and Monitoring Failures"
+	ServerSideRequestForgery                OWASPCategory = "A10:2021-Server-Side Request Forgery"
+)
+
+const (
+	Top25Size = 25
+
+	NameFormat = "CWE-%d"
+	URLFormat  = "https://cwe.mitre.org/data/definitions/%d.html"
+	RegexID    = `(?i)(?:cwe[-_/: ]?|definitions/)(\d+)`
+)
+
+func (o OWASPCategory) ToString() string {
+	return string(o)
+}
+
+// OWASPCategories returns the categories of the OWASP Top 10 2021, sorted by their rank.
+func OWASPCategories() []OWASPCategory {
+	return []OWASPCategory{
+		BrokenAccessControl,
+		CryptographicFailures,
+		Injection,
+		InsecureDesign,
+		SecurityMisconfiguration,
+		VulnerableAndOutdatedComponents,
+		IdentificationAndAuthenticationFailures,
+		SoftwareAndDataIntegrityFailures,
+		SecurityLoggingAndMonitoringFailures,
+		ServerSideRequestForgery,
+	}
+}
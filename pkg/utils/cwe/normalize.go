@@ -0,0 +1,68 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwe
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cwe/enums"
+)
+
+var idRegex = regexp.MustCompile(enums.RegexID)
+
+// ParseID returns the numeric id of a CWE reference, which could be an url of the mitre website, an identifier as
+// CWE-79 or only the number. The second value is false when no valid id is found.
+// Usage example: id, ok := ParseID("https://cwe.mitre.org/data/definitions/79.html")
+func ParseID(value string) (int, bool) {
+	value = strings.TrimSpace(value)
+	if match := idRegex.FindStringSubmatch(value); len(match) > 1 {
+		value = match[1]
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+
+	return id, true
+}
+
+// ParseIDs returns the numeric ids of the CWE references without duplications, keeping their order and ignoring
+// the invalid ones.
+func ParseIDs(values []string) (ids []int) {
+	found := map[int]bool{}
+
+	for _, value := range values {
+		if id, ok := ParseID(value); ok && !found[id] {
+			found[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// FormatID returns the identifier of the CWE id, like CWE-79.
+func FormatID(id int) string {
+	return fmt.Sprintf(enums.NameFormat, id)
+}
+
+// FormatURL returns the definition url of the CWE id on the mitre website.
+func FormatURL(id int) string {
+	return fmt.Sprintf(enums.URLFormat, id)
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseID(t *testing.T) {
+	t.Run("should parse the supported formats", func(t *testing.T) {
+		values := []string{"https://cwe.mitre.org/data/definitions/79.html", "CWE-79", "cwe_79", "CWE:79",
+			"cwe/79", " 79 ", "external/cwe/cwe-79"}
+
+		for _, value := range values {
+			id, ok := ParseID(value)
+
+			assert.True(t, ok, value)
+			assert.Equal(t, 79, id, value)
+		}
+	})
+
+	t.Run("should return false when invalid", func(t *testing.T) {
+		for _, value := range []string{"", "test", "CWE-", "0", "-1"} {
+			_, ok := ParseID(value)
+
+			assert.False(t, ok, value)
+		}
+	})
+}
+
+func TestParseIDs(t *testing.T) {
+	t.Run("should parse the ids without duplications", func(t *testing.T) {
+		ids := ParseIDs([]string{"CWE-89", "invalid", "https://cwe.mitre.org/data/definitions/79.html", "89"})
+
+		assert.Equal(t, []int{89, 79}, ids)
+	})
+}
+
+func TestFormat(t *testing.T) {
+	t.Run("should format the id and url", func(t *testing.T) {
+		assert.Equal(t, "CWE-22", FormatID(22))
+		assert.Equal(t, "https://cwe.mitre.org/data/definitions/22.html", FormatURL(22))
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoder
+
+import (
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+)
+
+// IEncoder renders an analysis into a report format, like the ones expected by the CI tools.
+type IEncoder interface {
+	Encode(entity *analysis.Analysis) ([]byte, error)
+	GetContentType() string
+}
+
+type encoder struct {
+	contentType string
+	encode      func(entity *analysis.Analysis) []byte
+}
+
+// NewEncoder creates an encoder from a function that can not fail, like the ToBytes of the reports.
+// Usage example: encoder.NewEncoder("application/json", func(a *analysis.Analysis) []byte { return a.ToBytes() })
+func NewEncoder(contentType string, encode func(entity *analysis.Analysis) []byte) IEncoder {
+	return &encoder{
+		contentType: contentType,
+		encode:      encode,
+	}
+}
+
+func (e *encoder) Encode(entity *analysis.Analysis) ([]byte, error) {
+	return e.encode(entity), nil
+}
+
+func (e *encoder) GetContentType() string {
+	return e.contentType
+}
@@ -0,0 +1,39 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+const (
+	Version    = "15.0.6"
+	ScanType   = "sast"
+	Category   = "sast"
+	TimeFormat = "2006-01-02T15:04:05"
+
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+
+	ScannerID      = "horusec"
+	ScannerName    = "Horusec"
+	ScannerVersion = "unknown"
+	VendorName     = "Horusec"
+
+	IdentifierTypeRule = "horusec_rule_id"
+	IdentifierTypeHash = "horusec_vuln_hash"
+	IdentifierTypeCWE  = "cwe"
+	IdentifierTypeCVE  = "cve"
+
+	SeverityUnknown = "Unknown"
+
+	IDFormat = "%s:%s:%s"
+)
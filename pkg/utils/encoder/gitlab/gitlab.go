@@ -0,0 +1,240 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	analysisEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cve"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cwe"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/encoder/gitlab/enums"
+)
+
+// Report is a GitLab SAST report, containing only the properties used by the Horusec export. The complete schema is
+// available at https://gitlab.com/gitlab-org/security-products/security-report-schemas
+type Report struct {
+	Version         string           `json:"version"`
+	Scan            Scan             `json:"scan"`
+	Vulnerabilities []*Vulnerability `json:"vulnerabilities"`
+}
+
+type Scan struct {
+	Analyzer  Scanner `json:"analyzer"`
+	Scanner   Scanner `json:"scanner"`
+	Type      string  `json:"type"`
+	StartTime string  `json:"start_time"`
+	EndTime   string  `json:"end_time"`
+	Status    string  `json:"status"`
+}
+
+type Scanner struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Vendor  Vendor `json:"vendor"`
+}
+
+type Vendor struct {
+	Name string `json:"name"`
+}
+
+type Vulnerability struct {
+	ID          string       `json:"id"`
+	Category    string       `json:"category"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Severity    string       `json:"severity"`
+	Solution    string       `json:"solution,omitempty"`
+	Location    Location     `json:"location"`
+	Identifiers []Identifier `json:"identifiers"`
+	Links       []Link       `json:"links,omitempty"`
+}
+
+type Location struct {
+	File      string `json:"file"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+type Identifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+type Link struct {
+	URL string `json:"url"`
+}
+
+// NewReport creates a GitLab SAST report with the vulnerabilities of the analysis. False positives, accepted risks
+// and corrected vulnerabilities are not added, since the report has no way to represent them.
+// Usage example: bytes := gitlab.NewReport(analysis).ToBytes()
+func NewReport(entity *analysis.Analysis) *Report {
+	report := &Report{Version: enums.Version, Scan: newScan(entity), Vulnerabilities: []*Vulnerability{}}
+
+	for index := range entity.AnalysisVulnerabilities {
+		vuln := &entity.AnalysisVulnerabilities[index].Vulnerability
+		if vuln.Type != "" && vuln.Type != vulnerabilityEnums.Vulnerability {
+			continue
+		}
+
+		report.Vulnerabilities = append(report.Vulnerabilities, newVulnerability(vuln))
+	}
+
+	return report
+}
+
+func (r *Report) ToBytes() []byte {
+	bytes, _ := json.MarshalIndent(r, "", "  ")
+
+	return bytes
+}
+
+func newScan(entity *analysis.Analysis) Scan {
+	scanner := Scanner{
+		ID:      enums.ScannerID,
+		Name:    enums.ScannerName,
+		Version: enums.ScannerVersion,
+		Vendor:  Vendor{Name: enums.VendorName},
+	}
+
+	return Scan{
+		Analyzer:  scanner,
+		Scanner:   scanner,
+		Type:      enums.ScanType,
+		StartTime: formatTime(entity.CreatedAt),
+		EndTime:   formatTime(entity.FinishedAt),
+		Status:    getStatus(entity.Status),
+	}
+}
+
+func newVulnerability(vuln *vulnerability.Vulnerability) *Vulnerability {
+	line, _ := strconv.Atoi(vuln.Line)
+
+	return &Vulnerability{
+		ID:          getID(vuln),
+		Category:    enums.Category,
+		Name:        getName(vuln),
+		Description: vuln.Details,
+		Severity:    getSeverity(vuln.Severity),
+		Solution:    vuln.Mitigation,
+		Location:    Location{File: vuln.File, StartLine: line, EndLine: line},
+		Identifiers: getIdentifiers(vuln),
+		Links:       getLinks(vuln),
+	}
+}
+
+// getID returns an id generated from the vulnerability hash, or from the rule id, file and line when it has no hash,
+// so the same vulnerability has the same id between analysis. The vulnerability id is not used since it changes
+// on every analysis, making gitlab consider the vulnerabilities as new ones.
+func getID(vuln *vulnerability.Vulnerability) string {
+	name := vuln.VulnHash
+	if name == "" {
+		name = fmt.Sprintf(enums.IDFormat, vuln.RuleID, vuln.File, vuln.Line)
+	}
+
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
+
+func getName(vuln *vulnerability.Vulnerability) string {
+	if vuln.RuleID != "" {
+		return vuln.RuleID
+	}
+
+	return vuln.SecurityTool.ToString()
+}
+
+// getIdentifiers returns the rule id, or the vulnerability hash when it has no rule id, followed by the CWEs and CVEs.
+func getIdentifiers(vuln *vulnerability.Vulnerability) []Identifier {
+	identifiers := []Identifier{{Type: enums.IdentifierTypeRule, Name: vuln.RuleID, Value: vuln.RuleID}}
+	if vuln.RuleID == "" {
+		identifiers = []Identifier{{Type: enums.IdentifierTypeHash, Name: vuln.VulnHash, Value: vuln.VulnHash}}
+	}
+
+	identifiers = append(identifiers, getCWEIdentifiers(vuln)...)
+
+	return append(identifiers, getCVEIdentifiers(vuln)...)
+}
+
+func getCWEIdentifiers(vuln *vulnerability.Vulnerability) (identifiers []Identifier) {
+	for _, cweID := range cwe.ParseIDs(vuln.CWEs) {
+		identifiers = append(identifiers, Identifier{Type: enums.IdentifierTypeCWE, Name: cwe.FormatID(cweID),
+			Value: strconv.Itoa(cweID), URL: cwe.FormatURL(cweID)})
+	}
+
+	return identifiers
+}
+
+func getCVEIdentifiers(vuln *vulnerability.Vulnerability) (identifiers []Identifier) {
+	for _, id := range cve.ParseIDs(strings.Join(vuln.CVEs, " ")) {
+		identifiers = append(identifiers, Identifier{Type: enums.IdentifierTypeCVE, Name: id, Value: id,
+			URL: cve.FormatURL(id)})
+	}
+
+	return identifiers
+}
+
+func getLinks(vuln *vulnerability.Vulnerability) []Link {
+	if vuln.Reference == "" {
+		return nil
+	}
+
+	return []Link{{URL: vuln.Reference}}
+}
+
+func getSeverity(severity severities.Severity) string {
+	gitlabSeverities := map[severities.Severity]string{
+		severities.Critical: "Critical",
+		severities.High:     "High",
+		severities.Medium:   "Medium",
+		severities.Low:      "Low",
+		severities.Info:     "Info",
+	}
+
+	if gitlabSeverity, ok := gitlabSeverities[severity]; ok {
+		return gitlabSeverity
+	}
+
+	return enums.SeverityUnknown
+}
+
+func getStatus(status analysisEnums.Status) string {
+	if status == analysisEnums.Error {
+		return enums.StatusFailure
+	}
+
+	return enums.StatusSuccess
+}
+
+// formatTime returns the time on the format required by the schema, using the current time when it is not filled.
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		value = time.Now()
+	}
+
+	return value.UTC().Format(enums.TimeFormat)
+}
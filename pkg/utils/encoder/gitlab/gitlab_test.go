@@ -0,0 +1,124 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	analysisEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/encoder/gitlab/enums"
+)
+
+func getTestAnalysis() *analysis.Analysis {
+	return &analysis.Analysis{
+		Status:     analysisEnums.Success,
+		CreatedAt:  time.Date(2021, 12, 30, 23, 59, 0, 0, time.UTC),
+		FinishedAt: time.Date(2021, 12, 30, 23, 59, 59, 0, time.UTC),
+		AnalysisVulnerabilities: []analysis.AnalysisVulnerabilities{
+			{Vulnerability: vulnerability.Vulnerability{VulnerabilityID: uuid.New(), RuleID: "HS-GO-1",
+				SecurityTool: tools.HorusecEngine, Severity: severities.High, Type: vulnerabilityEnums.Vulnerability,
+				File: "main.go", Line: "10", Details: "hardcoded password", Mitigation: "use a secret manager",
+				Reference: "https://example.com", CWEs: []string{"https://cwe.mitre.org/data/definitions/798.html"}}},
+			{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.GoSec, Severity: severities.Low,
+				Type: vulnerabilityEnums.RiskAccepted, File: "api.go", Line: "5"}},
+			{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.NpmAudit, Severity: severities.Unknown,
+				File: "package-lock.json", VulnHash: "hash", CVEs: []string{"cve-2021-23337"}}},
+		},
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	t.Run("should create a sast report with the scan data", func(t *testing.T) {
+		report := NewReport(getTestAnalysis())
+
+		assert.Equal(t, enums.Version, report.Version)
+		assert.Equal(t, enums.ScanType, report.Scan.Type)
+		assert.Equal(t, "2021-12-30T23:59:00", report.Scan.StartTime)
+		assert.Equal(t, "2021-12-30T23:59:59", report.Scan.EndTime)
+		assert.Equal(t, enums.StatusSuccess, report.Scan.Status)
+		assert.Equal(t, enums.ScannerID, report.Scan.Scanner.ID)
+		assert.Equal(t, report.Scan.Scanner, report.Scan.Analyzer)
+	})
+
+	t.Run("should add only the vulnerabilities not triaged", func(t *testing.T) {
+		entity := getTestAnalysis()
+
+		report := NewReport(entity)
+		assert.Len(t, report.Vulnerabilities, 2)
+
+		vuln := report.Vulnerabilities[0]
+		assert.Equal(t, uuid.NewSHA1(uuid.NameSpaceOID, []byte("HS-GO-1:main.go:10")).String(), vuln.ID)
+		assert.Equal(t, enums.Category, vuln.Category)
+		assert.Equal(t, "HS-GO-1", vuln.Name)
+		assert.Equal(t, "High", vuln.Severity)
+		assert.Equal(t, "use a secret manager", vuln.Solution)
+		assert.Equal(t, Location{File: "main.go", StartLine: 10, EndLine: 10}, vuln.Location)
+		assert.Equal(t, []Link{{URL: "https://example.com"}}, vuln.Links)
+		assert.Equal(t, []Identifier{
+			{Type: enums.IdentifierTypeRule, Name: "HS-GO-1", Value: "HS-GO-1"},
+			{Type: enums.IdentifierTypeCWE, Name: "CWE-798", Value: "798",
+				URL: "https://cwe.mitre.org/data/definitions/798.html"},
+		}, vuln.Identifiers)
+	})
+
+	t.Run("should identify the vulnerabilities without rule id by the hash", func(t *testing.T) {
+		vuln := NewReport(getTestAnalysis()).Vulnerabilities[1]
+
+		assert.Equal(t, uuid.NewSHA1(uuid.NameSpaceOID, []byte("hash")).String(), vuln.ID)
+		assert.Equal(t, "NpmAudit", vuln.Name)
+		assert.Equal(t, enums.SeverityUnknown, vuln.Severity)
+		assert.Equal(t, Location{File: "package-lock.json"}, vuln.Location)
+		assert.Equal(t, []Identifier{
+			{Type: enums.IdentifierTypeHash, Name: "hash", Value: "hash"},
+			{Type: enums.IdentifierTypeCVE, Name: "CVE-2021-23337", Value: "CVE-2021-23337",
+				URL: "https://nvd.nist.gov/vuln/detail/CVE-2021-23337"},
+		}, vuln.Identifiers)
+	})
+
+	t.Run("should keep the same ids between analysis", func(t *testing.T) {
+		first := NewReport(getTestAnalysis())
+		second := NewReport(getTestAnalysis())
+
+		assert.Equal(t, first.Vulnerabilities[0].ID, second.Vulnerabilities[0].ID)
+		assert.Equal(t, first.Vulnerabilities[1].ID, second.Vulnerabilities[1].ID)
+	})
+
+	t.Run("should set the scan as failure when analysis finished with error", func(t *testing.T) {
+		report := NewReport(&analysis.Analysis{Status: analysisEnums.Error})
+
+		assert.Equal(t, enums.StatusFailure, report.Scan.Status)
+		assert.NotEmpty(t, report.Scan.StartTime)
+		assert.Empty(t, report.Vulnerabilities)
+	})
+}
+
+func TestToBytes(t *testing.T) {
+	t.Run("should marshal the report to json", func(t *testing.T) {
+		report := &Report{}
+
+		assert.NoError(t, json.Unmarshal(NewReport(getTestAnalysis()).ToBytes(), report))
+		assert.Equal(t, NewReport(getTestAnalysis()).Vulnerabilities[1], report.Vulnerabilities[1])
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/encoder/junit/enums"
+)
+
+// TestSuites is a JUnit XML report, where each security tool is a test suite and each vulnerability is a test case.
+// Vulnerabilities are failures, while false positives, accepted risks and corrected vulnerabilities are skipped.
+type TestSuites struct {
+	XMLName    xml.Name     `xml:"testsuites"`
+	Name       string       `xml:"name,attr"`
+	Tests      int          `xml:"tests,attr"`
+	Failures   int          `xml:"failures,attr"`
+	Skipped    int          `xml:"skipped,attr"`
+	TestSuites []*TestSuite `xml:"testsuite"`
+}
+
+type TestSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Timestamp string      `xml:"timestamp,attr,omitempty"`
+	TestCases []*TestCase `xml:"testcase"`
+}
+
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Failure   *Failure `xml:"failure,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+}
+
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type Skipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// NewReport creates a JUnit XML report with one test case per vulnerability of the analysis.
+// Usage example: bytes := junit.NewReport(analysis).ToBytes()
+func NewReport(entity *analysis.Analysis) *TestSuites {
+	report := &TestSuites{Name: enums.TestSuitesName, TestSuites: []*TestSuite{}}
+	suites := map[string]*TestSuite{}
+
+	for index := range entity.AnalysisVulnerabilities {
+		vuln := &entity.AnalysisVulnerabilities[index].Vulnerability
+
+		report.getTestSuite(suites, vuln, entity).addTestCase(newTestCase(vuln))
+	}
+
+	report.setCounters()
+
+	return report
+}
+
+func (t *TestSuites) ToBytes() []byte {
+	bytes, _ := xml.MarshalIndent(t, "", "  ")
+
+	return append([]byte(xml.Header), bytes...)
+}
+
+func (t *TestSuites) getTestSuite(suites map[string]*TestSuite, vuln *vulnerability.Vulnerability,
+	entity *analysis.Analysis) *TestSuite {
+	suite, ok := suites[vuln.SecurityTool.ToString()]
+	if !ok {
+		suite = &TestSuite{Name: vuln.SecurityTool.ToString(), TestCases: []*TestCase{}}
+		if !entity.CreatedAt.IsZero() {
+			suite.Timestamp = entity.CreatedAt.UTC().Format(enums.TimeFormat)
+		}
+
+		suites[vuln.SecurityTool.ToString()] = suite
+		t.TestSuites = append(t.TestSuites, suite)
+	}
+
+	return suite
+}
+
+func (t *TestSuites) setCounters() {
+	for _, suite := range t.TestSuites {
+		t.Tests += suite.Tests
+		t.Failures += suite.Failures
+		t.Skipped += suite.Skipped
+	}
+}
+
+func (t *TestSuite) addTestCase(testCase *TestCase) {
+	t.Tests++
+
+	if testCase.Failure != nil {
+		t.Failures++
+	}
+
+	if testCase.Skipped != nil {
+		t.Skipped++
+	}
+
+	t.TestCases = append(t.TestCases, testCase)
+}
+
+func newTestCase(vuln *vulnerability.Vulnerability) *TestCase {
+	testCase := &TestCase{
+		Name:      fmt.Sprintf(enums.TestCaseName, getName(vuln), vuln.File, vuln.Line),
+		ClassName: vuln.File,
+	}
+
+	if vuln.Type != "" && vuln.Type != vulnerabilityEnums.Vulnerability {
+		testCase.Skipped = &Skipped{Message: vuln.Type.ToString()}
+
+		return testCase
+	}
+
+	testCase.Failure = &Failure{
+		Message: vuln.Details,
+		Type:    vuln.Severity.ToString(),
+		Text: fmt.Sprintf(enums.FailureText, vuln.File, vuln.Line, vuln.Column, vuln.Code, vuln.Details,
+			vuln.VulnHash),
+	}
+
+	return testCase
+}
+
+func getName(vuln *vulnerability.Vulnerability) string {
+	if vuln.RuleID != "" {
+		return vuln.RuleID
+	}
+
+	return vuln.SecurityTool.ToString()
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package junit
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+)
+
+func getTestAnalysis() *analysis.Analysis {
+	return &analysis.Analysis{
+		CreatedAt: time.Date(2021, 12, 30, 23, 59, 59, 0, time.UTC),
+		AnalysisVulnerabilities: []analysis.AnalysisVulnerabilities{
+			{Vulnerability: vulnerability.Vulnerability{RuleID: "HS-GO-1", SecurityTool: tools.HorusecEngine,
+				Severity: severities.High, Type: vulnerabilityEnums.Vulnerability, File: "main.go", Line: "10",
+				Column: "2", Code: "password := \"123\"", Details: "hardcoded password", VulnHash: "hash1"}},
+			{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.GoSec, Severity: severities.Low,
+				Type: vulnerabilityEnums.FalsePositive, File: "api.go", Line: "5", Details: "unhandled error"}},
+			{Vulnerability: vulnerability.Vulnerability{RuleID: "HS-GO-2", SecurityTool: tools.HorusecEngine,
+				Severity: severities.Medium, File: "other.go", Line: "3", Details: "weak hash"}},
+		},
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	t.Run("should create one test suite per security tool", func(t *testing.T) {
+		report := NewReport(getTestAnalysis())
+
+		assert.Equal(t, 3, report.Tests)
+		assert.Equal(t, 2, report.Failures)
+		assert.Equal(t, 1, report.Skipped)
+		assert.Len(t, report.TestSuites, 2)
+		assert.Equal(t, "HorusecEngine", report.TestSuites[0].Name)
+		assert.Equal(t, "2021-12-30T23:59:59", report.TestSuites[0].Timestamp)
+		assert.Equal(t, 2, report.TestSuites[0].Failures)
+		assert.Equal(t, 1, report.TestSuites[1].Skipped)
+	})
+
+	t.Run("should create a failed test case for the vulnerabilities", func(t *testing.T) {
+		testCase := NewReport(getTestAnalysis()).TestSuites[0].TestCases[0]
+
+		assert.Equal(t, "HS-GO-1 main.go:10", testCase.Name)
+		assert.Equal(t, "main.go", testCase.ClassName)
+		assert.Equal(t, "hardcoded password", testCase.Failure.Message)
+		assert.Equal(t, "HIGH", testCase.Failure.Type)
+		assert.Contains(t, testCase.Failure.Text, "VulnHash: hash1")
+		assert.Nil(t, testCase.Skipped)
+	})
+
+	t.Run("should create a skipped test case for the triaged vulnerabilities", func(t *testing.T) {
+		testCase := NewReport(getTestAnalysis()).TestSuites[1].TestCases[0]
+
+		assert.Equal(t, "GoSec api.go:5", testCase.Name)
+		assert.Equal(t, &Skipped{Message: "False Positive"}, testCase.Skipped)
+		assert.Nil(t, testCase.Failure)
+	})
+}
+
+func TestToBytes(t *testing.T) {
+	t.Run("should marshal the report to xml", func(t *testing.T) {
+		bytes := NewReport(getTestAnalysis()).ToBytes()
+		report := &TestSuites{}
+
+		assert.Contains(t, string(bytes), xml.Header)
+		assert.NoError(t, xml.Unmarshal(bytes, report))
+		assert.Equal(t, 3, report.Tests)
+		assert.Len(t, report.TestSuites[0].TestCases, 2)
+	})
+
+	t.Run("should marshal an empty report", func(t *testing.T) {
+		assert.Contains(t, string(NewReport(&analysis.Analysis{}).ToBytes()), `tests="0"`)
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/encoder/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/encoder/gitlab"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/encoder/junit"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/encoder/sonarqube"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sarif"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sbom"
+)
+
+// IRegistry contains the encoders by format name, allowing the services to pick the report format by a parameter.
+type IRegistry interface {
+	Register(format string, encoder IEncoder)
+	Get(format string) (IEncoder, error)
+	GetFormats() []string
+	Encode(format string, entity *analysis.Analysis) ([]byte, error)
+}
+
+type Registry struct {
+	mutex    sync.RWMutex
+	encoders map[string]IEncoder
+}
+
+// NewRegistry creates a registry with the encoders of all formats supported by Horusec, which are json, sarif,
+// cyclonedx, spdx, junit, gitlab-sast and sonarqube.
+// Usage example: bytes, err := encoder.NewRegistry().Encode(request.URL.Query().Get("format"), analysis)
+func NewRegistry() IRegistry {
+	registry := &Registry{encoders: map[string]IEncoder{}}
+
+	registry.registerExportFormats()
+	registry.registerCIFormats()
+
+	return registry
+}
+
+func (r *Registry) registerExportFormats() {
+	r.Register(enums.FormatJSON, NewEncoder(enums.ContentTypeJSON, func(entity *analysis.Analysis) []byte {
+		return entity.ToBytes()
+	}))
+	r.Register(enums.FormatSARIF, NewEncoder(enums.ContentTypeJSON, func(entity *analysis.Analysis) []byte {
+		return sarif.NewReport(entity).ToBytes()
+	}))
+	r.Register(enums.FormatCycloneDX, NewEncoder(enums.ContentTypeJSON, func(entity *analysis.Analysis) []byte {
+		return sbom.NewCycloneDX(entity).ToBytes()
+	}))
+	r.Register(enums.FormatSPDX, NewEncoder(enums.ContentTypeJSON, func(entity *analysis.Analysis) []byte {
+		return sbom.NewSPDX(entity).ToBytes()
+	}))
+}
+
+func (r *Registry) registerCIFormats() {
+	r.Register(enums.FormatJUnit, NewEncoder(enums.ContentTypeXML, func(entity *analysis.Analysis) []byte {
+		return junit.NewReport(entity).ToBytes()
+	}))
+	r.Register(enums.FormatGitLab, NewEncoder(enums.ContentTypeJSON, func(entity *analysis.Analysis) []byte {
+		return gitlab.NewReport(entity).ToBytes()
+	}))
+	r.Register(enums.FormatSonarQube, NewEncoder(enums.ContentTypeJSON, func(entity *analysis.Analysis) []byte {
+		return sonarqube.NewReport(entity).ToBytes()
+	}))
+}
+
+// Register adds the encoder of the format, replacing the existing one when already registered. Format names are
+// case insensitive.
+func (r *Registry) Register(format string, encoder IEncoder) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.encoders[strings.ToLower(format)] = encoder
+}
+
+// Get returns the encoder of the format, or enums.ErrorUnknownFormat when not registered.
+func (r *Registry) Get(format string) (IEncoder, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	encoder, ok := r.encoders[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", enums.ErrorUnknownFormat, format)
+	}
+
+	return encoder, nil
+}
+
+// GetFormats returns the names of all registered formats in alphabetical order.
+func (r *Registry) GetFormats() (formats []string) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for format := range r.encoders {
+		formats = append(formats, format)
+	}
+
+	sort.Strings(formats)
+
+	return formats
+}
+
+func (r *Registry) Encode(format string, entity *analysis.Analysis) ([]byte, error) {
+	encoder, err := r.Get(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return encoder.Encode(entity)
+}
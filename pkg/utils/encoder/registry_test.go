@@ -0,0 +1,113 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoder
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/encoder/enums"
+)
+
+type testEncoder struct{}
+
+func (t *testEncoder) Encode(_ *analysis.Analysis) ([]byte, error) {
+	return nil, errors.New("test")
+}
+
+func (t *testEncoder) GetContentType() string {
+	return "text/plain"
+}
+
+func getTestAnalysis() *analysis.Analysis {
+	return &analysis.Analysis{AnalysisVulnerabilities: []analysis.AnalysisVulnerabilities{
+		{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.NpmAudit, Code: "lodash@4.17.20"}},
+	}}
+}
+
+func TestNewRegistry(t *testing.T) {
+	t.Run("should register all supported formats", func(t *testing.T) {
+		assert.Equal(t, []string{"cyclonedx", "gitlab-sast", "json", "junit", "sarif", "sonarqube", "spdx"},
+			NewRegistry().GetFormats())
+	})
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("should encode the analysis with the json formats", func(t *testing.T) {
+		registry := NewRegistry()
+
+		for _, format := range []string{enums.FormatJSON, enums.FormatSARIF, enums.FormatCycloneDX, enums.FormatSPDX,
+			enums.FormatGitLab, enums.FormatSonarQube} {
+			bytes, err := registry.Encode(format, getTestAnalysis())
+
+			assert.NoError(t, err)
+			assert.True(t, json.Valid(bytes), format)
+		}
+	})
+
+	t.Run("should encode the analysis with the junit format", func(t *testing.T) {
+		bytes, err := NewRegistry().Encode("JUnit", getTestAnalysis())
+
+		assert.NoError(t, err)
+		assert.NoError(t, xml.Unmarshal(bytes, &struct{}{}))
+	})
+
+	t.Run("should return error when unknown format", func(t *testing.T) {
+		_, err := NewRegistry().Encode("pdf", getTestAnalysis())
+
+		assert.ErrorIs(t, err, enums.ErrorUnknownFormat)
+	})
+
+	t.Run("should return the error of the encoder", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("text", &testEncoder{})
+
+		_, err := registry.Encode("text", getTestAnalysis())
+
+		assert.EqualError(t, err, "test")
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("should return the content type of the formats", func(t *testing.T) {
+		registry := NewRegistry()
+
+		junitEncoder, err := registry.Get(enums.FormatJUnit)
+		assert.NoError(t, err)
+		assert.Equal(t, enums.ContentTypeXML, junitEncoder.GetContentType())
+
+		sarifEncoder, err := registry.Get(enums.FormatSARIF)
+		assert.NoError(t, err)
+		assert.Equal(t, enums.ContentTypeJSON, sarifEncoder.GetContentType())
+	})
+
+	t.Run("should replace the encoder when already registered", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("JSON", &testEncoder{})
+
+		encoder, err := registry.Get(enums.FormatJSON)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "text/plain", encoder.GetContentType())
+		assert.Len(t, registry.GetFormats(), 7)
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/encoder/sonarqube/enums"
+)
+
+// Report is a SonarQube generic issue import report, where the security tool is the engine of each issue. The
+// complete format is available at https://docs.sonarqube.org/latest/analyzing-source-code/importing-external-issues
+type Report struct {
+	Issues []*Issue `json:"issues"`
+}
+
+type Issue struct {
+	EngineID        string   `json:"engineId"`
+	RuleID          string   `json:"ruleId"`
+	Severity        string   `json:"severity"`
+	Type            string   `json:"type"`
+	PrimaryLocation Location `json:"primaryLocation"`
+}
+
+type Location struct {
+	Message   string     `json:"message"`
+	FilePath  string     `json:"filePath"`
+	TextRange *TextRange `json:"textRange,omitempty"`
+}
+
+type TextRange struct {
+	StartLine int `json:"startLine"`
+}
+
+// NewReport creates a SonarQube generic issue report with the vulnerabilities of the analysis. False positives,
+// accepted risks and corrected vulnerabilities are not added, since they should not be reported as issues.
+// Usage example: bytes := sonarqube.NewReport(analysis).ToBytes()
+func NewReport(entity *analysis.Analysis) *Report {
+	report := &Report{Issues: []*Issue{}}
+
+	for index := range entity.AnalysisVulnerabilities {
+		vuln := &entity.AnalysisVulnerabilities[index].Vulnerability
+		if vuln.Type != "" && vuln.Type != vulnerabilityEnums.Vulnerability {
+			continue
+		}
+
+		report.Issues = append(report.Issues, newIssue(vuln))
+	}
+
+	return report
+}
+
+func (r *Report) ToBytes() []byte {
+	bytes, _ := json.MarshalIndent(r, "", "  ")
+
+	return bytes
+}
+
+func newIssue(vuln *vulnerability.Vulnerability) *Issue {
+	return &Issue{
+		EngineID:        vuln.SecurityTool.ToString(),
+		RuleID:          getRuleID(vuln),
+		Severity:        getSeverity(vuln.Severity),
+		Type:            enums.IssueType,
+		PrimaryLocation: newLocation(vuln),
+	}
+}
+
+// newLocation returns the location of the vulnerability, where the text range is only set with valid lines, since
+// SonarQube rejects the whole report when a line is lower than one.
+func newLocation(vuln *vulnerability.Vulnerability) Location {
+	location := Location{Message: vuln.Details, FilePath: vuln.File}
+	if location.Message == "" {
+		location.Message = fmt.Sprintf(enums.MessageWithoutDetails, getRuleID(vuln))
+	}
+
+	if line, _ := strconv.Atoi(vuln.Line); line > 0 {
+		location.TextRange = &TextRange{StartLine: line}
+	}
+
+	return location
+}
+
+func getRuleID(vuln *vulnerability.Vulnerability) string {
+	if vuln.RuleID != "" {
+		return vuln.RuleID
+	}
+
+	return vuln.SecurityTool.ToString()
+}
+
+func getSeverity(severity severities.Severity) string {
+	sonarSeverities := map[severities.Severity]string{
+		severities.Critical: enums.SeverityBlocker,
+		severities.High:     enums.SeverityCritical,
+		severities.Medium:   enums.SeverityMajor,
+		severities.Low:      enums.SeverityMinor,
+	}
+
+	if sonarSeverity, ok := sonarSeverities[severity]; ok {
+		return sonarSeverity
+	}
+
+	return enums.SeverityInfo
+}
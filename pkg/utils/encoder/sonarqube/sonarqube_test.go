@@ -0,0 +1,88 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sonarqube
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/encoder/sonarqube/enums"
+)
+
+func getTestAnalysis() *analysis.Analysis {
+	return &analysis.Analysis{AnalysisVulnerabilities: []analysis.AnalysisVulnerabilities{
+		{Vulnerability: vulnerability.Vulnerability{RuleID: "HS-GO-1", SecurityTool: tools.HorusecEngine,
+			Severity: severities.Critical, Type: vulnerabilityEnums.Vulnerability, File: "main.go", Line: "10",
+			Details: "hardcoded password"}},
+		{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.GoSec, Severity: severities.Low,
+			Type: vulnerabilityEnums.Corrected, File: "api.go", Line: "5"}},
+		{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.NpmAudit, Severity: severities.Info,
+			File: "package-lock.json", Line: "-"}},
+	}}
+}
+
+func TestNewReport(t *testing.T) {
+	t.Run("should add only the vulnerabilities not triaged", func(t *testing.T) {
+		report := NewReport(getTestAnalysis())
+
+		assert.Len(t, report.Issues, 2)
+		assert.Equal(t, &Issue{
+			EngineID: "HorusecEngine",
+			RuleID:   "HS-GO-1",
+			Severity: enums.SeverityBlocker,
+			Type:     enums.IssueType,
+			PrimaryLocation: Location{
+				Message:   "hardcoded password",
+				FilePath:  "main.go",
+				TextRange: &TextRange{StartLine: 10},
+			},
+		}, report.Issues[0])
+	})
+
+	t.Run("should omit the text range and fill the message when missing", func(t *testing.T) {
+		issue := NewReport(getTestAnalysis()).Issues[1]
+
+		assert.Equal(t, "NpmAudit", issue.RuleID)
+		assert.Equal(t, enums.SeverityInfo, issue.Severity)
+		assert.Equal(t, Location{Message: "NpmAudit vulnerability", FilePath: "package-lock.json"},
+			issue.PrimaryLocation)
+	})
+}
+
+func TestGetSeverity(t *testing.T) {
+	t.Run("should map the severities to the sonarqube severities", func(t *testing.T) {
+		assert.Equal(t, enums.SeverityBlocker, getSeverity(severities.Critical))
+		assert.Equal(t, enums.SeverityCritical, getSeverity(severities.High))
+		assert.Equal(t, enums.SeverityMajor, getSeverity(severities.Medium))
+		assert.Equal(t, enums.SeverityMinor, getSeverity(severities.Low))
+		assert.Equal(t, enums.SeverityInfo, getSeverity(severities.Unknown))
+	})
+}
+
+func TestToBytes(t *testing.T) {
+	t.Run("should marshal the report to json", func(t *testing.T) {
+		report := &Report{}
+
+		assert.NoError(t, json.Unmarshal(NewReport(getTestAnalysis()).ToBytes(), report))
+		assert.Equal(t, NewReport(getTestAnalysis()), report)
+	})
+}
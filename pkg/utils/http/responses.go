@@ -103,6 +103,22 @@ func StatusUnprocessableEntity(w http.ResponseWriter, err error) {
 	setResponseWriter(w, response)
 }
 
+func StatusServiceUnavailable(w http.ResponseWriter, err error) {
+	response := &httpEntities.Response{}
+	response.SetResponseData(http.StatusServiceUnavailable,
+		http.StatusText(http.StatusServiceUnavailable), getErrorMessage(err))
+
+	setResponseWriter(w, response)
+}
+
+func StatusGatewayTimeout(w http.ResponseWriter, err error) {
+	response := &httpEntities.Response{}
+	response.SetResponseData(http.StatusGatewayTimeout,
+		http.StatusText(http.StatusGatewayTimeout), getErrorMessage(err))
+
+	setResponseWriter(w, response)
+}
+
 func setResponseWriter(w http.ResponseWriter, response *httpEntities.Response) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
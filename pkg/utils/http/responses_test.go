@@ -193,3 +193,25 @@ func TestStatusUnprocessableEntity(t *testing.T) {
 		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 	})
 }
+
+func TestStatusServiceUnavailable(t *testing.T) {
+	t.Run("should return status code 503", func(t *testing.T) {
+		_, _ = http.NewRequest(http.MethodPost, "/test", nil)
+		w := httptest.NewRecorder()
+
+		StatusServiceUnavailable(w, errors.New("test"))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
+func TestStatusGatewayTimeout(t *testing.T) {
+	t.Run("should return status code 504", func(t *testing.T) {
+		_, _ = http.NewRequest(http.MethodPost, "/test", nil)
+		w := httptest.NewRecorder()
+
+		StatusGatewayTimeout(w, errors.New("test"))
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	})
+}
@@ -154,6 +154,14 @@ func LogTraceWithLevel(msg string, args ...interface{}) {
 	}
 }
 
+// LogWithFields logs the message with the structured fields when the level is enabled, being useful for entries
+// that are consumed by log aggregators, like the database queries.
+func LogWithFields(level logrus.Level, msg string, fields map[string]interface{}) {
+	if logrus.IsLevelEnabled(level) {
+		logrus.WithFields(fields).Log(level, msg)
+	}
+}
+
 func LogStringAsError(msg string) {
 	logrus.Error(msg)
 }
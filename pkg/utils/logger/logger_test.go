@@ -174,6 +174,27 @@ func TestLogDebugJSON(t *testing.T) {
 	})
 }
 
+func TestLogWithFields(t *testing.T) {
+	t.Run("should log the message with the fields when level is enabled", func(t *testing.T) {
+		output := bytes.NewBufferString("")
+		LogSetOutput(output)
+
+		LogWithFields(enums.WarnLevel, "test", map[string]interface{}{"rows": 1})
+
+		assert.Contains(t, output.String(), "rows=1")
+	})
+
+	t.Run("should not log when level is disabled", func(t *testing.T) {
+		output := bytes.NewBufferString("")
+		LogSetOutput(output)
+		SetLogLevel(enums.InfoLevel.String())
+
+		LogWithFields(enums.TraceLevel, "test", map[string]interface{}{"rows": 1})
+
+		assert.Empty(t, output.String())
+	})
+}
+
 func TestLogSetOutput(t *testing.T) {
 	t.Run("Should set output instance without file and get on read output", func(t *testing.T) {
 		output := bytes.NewBufferString("")
@@ -0,0 +1,87 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mageutils
+
+import (
+	"os"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/config"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/migrations"
+	"github.com/Fotkurz/horusec-devkit/pkg/services/database/migrations/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/env"
+)
+
+// MigrateUp applies all pending migrations of $HORUSEC_DATABASE_SQL_MIGRATIONS_PATH into $HORUSEC_DATABASE_SQL_URI
+func MigrateUp() error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+
+	_, err = migrator.Up()
+
+	return err
+}
+
+// MigrateDown reverts the last applied migration of $HORUSEC_DATABASE_SQL_MIGRATIONS_PATH
+func MigrateDown() error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+
+	_, err = migrator.Down()
+
+	return err
+}
+
+// MigrateTo applies or reverts the migrations of $HORUSEC_DATABASE_SQL_MIGRATIONS_PATH until the given version,
+// which must not be negative
+func MigrateTo(version int) error {
+	if version < 0 {
+		return enums.ErrorInvalidMigrationVersion
+	}
+
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+
+	_, err = migrator.To(uint64(version))
+
+	return err
+}
+
+// MigrateUnlock releases the migrations lock left by an interrupted migration
+func MigrateUnlock() error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+
+	return migrator.ForceUnlock()
+}
+
+func newMigrator() (migrations.IMigrator, error) {
+	migrator, err := migrations.NewMigratorFromConfig(config.NewDatabaseConfig(),
+		os.DirFS(env.GetEnvOrDefault(enums.EnvMigrationsPath, enums.DefaultMigrationsPath)))
+	if err != nil {
+		return nil, err
+	}
+
+	migrator.SetDryRun(env.GetEnvOrDefaultBool(enums.EnvMigrationsDryRun, false))
+
+	return migrator, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/pagination/enums"
+)
+
+// CursorPage is the result of a keyset pagination. The NextCursor should be sent back to get the following page
+// and is empty when there are no more records.
+type CursorPage struct {
+	Items      interface{} `json:"items"`
+	NextCursor string      `json:"nextCursor"`
+	HasMore    bool        `json:"hasMore"`
+}
+
+// typedValue is a cursor value whose type is lost by json, like time.Time, encoded with its type name so it is
+// decoded back into the same type. Otherwise, times would be compared as strings by databases like sqlite.
+type typedValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// EncodeCursor generates an opaque cursor containing the sort key values of the last record of a page.
+func EncodeCursor(values ...interface{}) (string, error) {
+	encoded := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		encoded = append(encoded, encodeCursorValue(value))
+	}
+
+	content, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+// DecodeCursor returns the sort key values of a cursor generated by EncodeCursor. Numbers without decimals are
+// returned as int64 to avoid losing precision of big identifiers, times are returned as time.Time and an empty cursor
+// returns no values.
+func DecodeCursor(cursor string) ([]interface{}, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	content, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", enums.ErrorInvalidCursor, err)
+	}
+
+	return decodeCursorValues(content)
+}
+
+func decodeCursorValues(content []byte) ([]interface{}, error) {
+	var values []interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&values); err != nil || len(values) == 0 {
+		return nil, enums.ErrorInvalidCursor
+	}
+
+	for index, value := range values {
+		decoded, err := decodeCursorValue(value)
+		if err != nil {
+			return nil, err
+		}
+
+		values[index] = decoded
+	}
+
+	return values, nil
+}
+
+func encodeCursorValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case time.Time:
+		return &typedValue{Type: enums.CursorTypeTime, Value: typed.Format(time.RFC3339Nano)}
+	case *time.Time:
+		if typed != nil {
+			return encodeCursorValue(*typed)
+		}
+	}
+
+	return value
+}
+
+func decodeCursorValue(value interface{}) (interface{}, error) {
+	switch typed := value.(type) {
+	case json.Number:
+		return parseNumber(typed), nil
+	case map[string]interface{}:
+		return parseTypedValue(typed)
+	}
+
+	return value, nil
+}
+
+func parseTypedValue(value map[string]interface{}) (interface{}, error) {
+	content, _ := value["value"].(string)
+	if value["type"] != enums.CursorTypeTime {
+		return nil, enums.ErrorInvalidCursor
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", enums.ErrorInvalidCursor, err)
+	}
+
+	return parsed, nil
+}
+
+func parseNumber(number json.Number) interface{} {
+	if value, err := number.Int64(); err == nil {
+		return value
+	}
+
+	value, _ := number.Float64()
+
+	return value
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/pagination/enums"
+)
+
+func TestEncodeAndDecodeCursor(t *testing.T) {
+	t.Run("should success encode and decode cursor values", func(t *testing.T) {
+		cursor, err := EncodeCursor("2021-12-30T23:59:59Z", int64(9007199254740993), 1.5, "test")
+		assert.NoError(t, err)
+		assert.NotContains(t, cursor, "test")
+
+		values, err := DecodeCursor(cursor)
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{"2021-12-30T23:59:59Z", int64(9007199254740993), 1.5, "test"}, values)
+	})
+
+	t.Run("should decode the times back into time values", func(t *testing.T) {
+		createdAt := time.Date(2021, 12, 30, 23, 59, 59, 123456789, time.FixedZone("BRT", -3*60*60))
+
+		cursor, err := EncodeCursor(createdAt, &createdAt, (*time.Time)(nil), "2021-12-30T23:59:59Z")
+		assert.NoError(t, err)
+
+		values, err := DecodeCursor(cursor)
+		assert.NoError(t, err)
+		assert.Len(t, values, 4)
+		assert.True(t, createdAt.Equal(values[0].(time.Time)))
+		assert.True(t, createdAt.Equal(values[1].(time.Time)))
+		assert.Nil(t, values[2])
+		assert.Equal(t, "2021-12-30T23:59:59Z", values[3])
+	})
+
+	t.Run("should return error when failed to encode values", func(t *testing.T) {
+		_, err := EncodeCursor(make(chan int))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return no values when cursor is empty", func(t *testing.T) {
+		values, err := DecodeCursor("")
+
+		assert.NoError(t, err)
+		assert.Nil(t, values)
+	})
+
+	t.Run("should return error when cursor is invalid", func(t *testing.T) {
+		invalidTypes := []string{`[{"type":"test","value":"test"}]`, `[{"type":"time","value":"test"}]`}
+		for _, content := range invalidTypes {
+			_, err := DecodeCursor(base64.RawURLEncoding.EncodeToString([]byte(content)))
+
+			assert.True(t, errors.Is(err, enums.ErrorInvalidCursor), content)
+		}
+
+		for _, cursor := range []string{"!!!", "e30", "W10"} {
+			_, err := DecodeCursor(cursor)
+
+			assert.True(t, errors.Is(err, enums.ErrorInvalidCursor))
+		}
+	})
+}
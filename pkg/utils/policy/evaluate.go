@@ -0,0 +1,128 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/policy/enums"
+)
+
+// Result is the verdict of a policy, containing the violated rules with the vulnerabilities selected by them.
+type Result struct {
+	Passed     bool         `json:"passed"`
+	Violations []*Violation `json:"violations"`
+}
+
+type Violation struct {
+	Rule            *Rule                          `json:"rule"`
+	Count           int                            `json:"count"`
+	Vulnerabilities []*vulnerability.Vulnerability `json:"vulnerabilities"`
+}
+
+// Evaluate checks the vulnerabilities of the analysis against all rules of the policy, passing only when no rule is
+// violated.
+// Usage example: if result := policy.Evaluate(analysis); !result.Passed { ... }
+func (p *Policy) Evaluate(entity *analysis.Analysis) *Result {
+	result := &Result{Passed: true, Violations: []*Violation{}}
+
+	for _, rule := range p.Rules {
+		if violation := rule.Evaluate(entity); violation != nil {
+			result.Passed = false
+			result.Violations = append(result.Violations, violation)
+		}
+	}
+
+	return result
+}
+
+// GetMessages returns a message for each violation, which can be printed by the CLI or returned by the services.
+func (r *Result) GetMessages() (messages []string) {
+	for _, violation := range r.Violations {
+		messages = append(messages, violation.GetMessage())
+	}
+
+	return messages
+}
+
+func (v *Violation) GetMessage() string {
+	return fmt.Sprintf(enums.MessageViolation, v.Rule.Name, v.Count, v.Rule.MaxCount)
+}
+
+// Evaluate returns the violation of the rule when the analysis has more vulnerabilities matching the rule than the
+// allowed, or nil when the rule is not violated.
+func (r *Rule) Evaluate(entity *analysis.Analysis) *Violation {
+	violation := &Violation{Rule: r, Vulnerabilities: []*vulnerability.Vulnerability{}}
+
+	for index := range entity.AnalysisVulnerabilities {
+		if vuln := &entity.AnalysisVulnerabilities[index].Vulnerability; r.Match(vuln) {
+			violation.Vulnerabilities = append(violation.Vulnerabilities, vuln)
+		}
+	}
+
+	violation.Count = len(violation.Vulnerabilities)
+	if violation.Count <= r.MaxCount {
+		return nil
+	}
+
+	return violation
+}
+
+// Match returns true when the vulnerability matches all filters of the rule.
+func (r *Rule) Match(vuln *vulnerability.Vulnerability) bool {
+	return matchValues(r.Severities, vuln.Severity) &&
+		matchValues(r.Confidences, vuln.Confidence) &&
+		matchValues(r.Languages, vuln.Language) &&
+		matchValues(r.Tools, vuln.SecurityTool) &&
+		matchValues(r.Types, vuln.Type) &&
+		!containsValue(r.ExcludeTypes, vuln.Type) &&
+		r.matchFiles(vuln.File)
+}
+
+func (r *Rule) matchFiles(file string) bool {
+	files, excludeFiles := r.getFileGlobs()
+	if matchGlobs(excludeFiles, file) {
+		return false
+	}
+
+	return len(files) == 0 || matchGlobs(files, file)
+}
+
+// getFileGlobs returns the globs compiled by Validate, or compiles them when the rule was not validated.
+func (r *Rule) getFileGlobs() (files, excludeFiles []*regexp.Regexp) {
+	if len(r.files) != len(r.Files) || len(r.excludeFiles) != len(r.ExcludeFiles) {
+		return compileGlobs(r.Files), compileGlobs(r.ExcludeFiles)
+	}
+
+	return r.files, r.excludeFiles
+}
+
+// matchValues returns true when the filter is empty or contains the value.
+func matchValues[T comparable](filter []T, value T) bool {
+	return len(filter) == 0 || containsValue(filter, value)
+}
+
+func containsValue[T comparable](values []T, value T) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	vulnerabilityEntity "github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/confidence"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/languages"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+)
+
+func newTestAnalysis(vulnerabilities ...vulnerabilityEntity.Vulnerability) *analysis.Analysis {
+	entity := &analysis.Analysis{}
+
+	for _, vuln := range vulnerabilities {
+		entity.AnalysisVulnerabilities = append(entity.AnalysisVulnerabilities,
+			analysis.AnalysisVulnerabilities{Vulnerability: vuln})
+	}
+
+	return entity
+}
+
+func newGoVulnerabilities(count int, file string) (vulnerabilities []vulnerabilityEntity.Vulnerability) {
+	for index := 0; index < count; index++ {
+		vulnerabilities = append(vulnerabilities, vulnerabilityEntity.Vulnerability{Severity: severities.High,
+			Language: languages.Go, Type: vulnerability.Vulnerability, File: fmt.Sprintf(file, index)})
+	}
+
+	return vulnerabilities
+}
+
+func TestEvaluate(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicyYAML))
+	assert.NoError(t, err)
+
+	t.Run("should pass when no rule is violated", func(t *testing.T) {
+		result := policy.Evaluate(newTestAnalysis(newGoVulnerabilities(5, "main%d.go")...))
+
+		assert.True(t, result.Passed)
+		assert.Empty(t, result.Violations)
+		assert.Empty(t, result.GetMessages())
+	})
+
+	t.Run("should fail when more vulnerabilities than allowed", func(t *testing.T) {
+		result := policy.Evaluate(newTestAnalysis(newGoVulnerabilities(6, "main%d.go")...))
+
+		assert.False(t, result.Passed)
+		assert.Len(t, result.Violations, 1)
+		assert.Equal(t, 6, result.Violations[0].Count)
+		assert.Len(t, result.Violations[0].Vulnerabilities, 6)
+		assert.Equal(t, []string{`policy rule "high go" violated: 6 vulnerabilities found, maximum allowed is 5`},
+			result.GetMessages())
+	})
+
+	t.Run("should ignore the excluded files", func(t *testing.T) {
+		vulnerabilities := append(newGoVulnerabilities(5, "main%d.go"), newGoVulnerabilities(5, "pkg/main%d_test.go")...)
+
+		assert.True(t, policy.Evaluate(newTestAnalysis(vulnerabilities...)).Passed)
+	})
+
+	t.Run("should fail when any vulnerability and ignore the excluded types", func(t *testing.T) {
+		result := policy.Evaluate(newTestAnalysis(
+			vulnerabilityEntity.Vulnerability{Severity: severities.Critical, Language: languages.Leaks,
+				Type: vulnerability.RiskAccepted},
+			vulnerabilityEntity.Vulnerability{Severity: severities.Critical, Language: languages.Leaks,
+				Type: vulnerability.Vulnerability},
+		))
+
+		assert.False(t, result.Passed)
+		assert.Equal(t, "critical leaks", result.Violations[0].Rule.Name)
+		assert.Equal(t, 1, result.Violations[0].Count)
+	})
+}
+
+func TestMatch(t *testing.T) {
+	vuln := &vulnerabilityEntity.Vulnerability{Severity: severities.High, Confidence: confidence.Low,
+		Language: languages.Python, SecurityTool: tools.Bandit, Type: vulnerability.FalsePositive, File: "app/main.py"}
+
+	t.Run("should match when all filters match", func(t *testing.T) {
+		assert.True(t, (&Rule{}).Match(vuln))
+		assert.True(t, (&Rule{
+			Severities:  []severities.Severity{severities.Critical, severities.High},
+			Confidences: []confidence.Confidence{confidence.Low},
+			Languages:   []languages.Language{languages.Python},
+			Tools:       []tools.Tool{tools.Bandit},
+			Types:       []vulnerability.Type{vulnerability.FalsePositive},
+			Files:       []string{"app/**"},
+		}).Match(vuln))
+	})
+
+	t.Run("should not match when any filter does not match", func(t *testing.T) {
+		assert.False(t, (&Rule{Confidences: []confidence.Confidence{confidence.High}}).Match(vuln))
+		assert.False(t, (&Rule{Tools: []tools.Tool{tools.Semgrep}}).Match(vuln))
+		assert.False(t, (&Rule{Types: []vulnerability.Type{vulnerability.Vulnerability}}).Match(vuln))
+		assert.False(t, (&Rule{Files: []string{"cmd/**"}}).Match(vuln))
+		assert.False(t, (&Rule{ExcludeFiles: []string{"**/*.py"}}).Match(vuln))
+	})
+}
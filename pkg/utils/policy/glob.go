@@ -0,0 +1,84 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// compileGlob converts a file glob into a regular expression matching the whole path, where "**" matches any number
+// of directories, "*" matches any characters except the separator and "?" matches a single character except the
+// separator, like "**/*_test.go" or "internal/**".
+func compileGlob(glob string) *regexp.Regexp {
+	var builder strings.Builder
+
+	builder.WriteString("^")
+
+	for index := 0; index < len(glob); {
+		expression, size := getGlobExpression(glob[index:])
+
+		builder.WriteString(expression)
+		index += size
+	}
+
+	builder.WriteString("$")
+
+	return regexp.MustCompile(builder.String())
+}
+
+// getGlobExpression returns the regular expression of the glob token at the beginning of the value and its size.
+func getGlobExpression(value string) (expression string, size int) {
+	switch {
+	case strings.HasPrefix(value, "**/"):
+		return "(?:.*/)?", len("**/")
+	case strings.HasPrefix(value, "**"):
+		return ".*", len("**")
+	case value[0] == '*':
+		return "[^/]*", 1
+	case value[0] == '?':
+		return "[^/]", 1
+	default:
+		_, size = utf8.DecodeRuneInString(value)
+
+		return regexp.QuoteMeta(value[:size]), size
+	}
+}
+
+// compileGlobs compiles each one of the file globs, see compileGlob.
+func compileGlobs(globs []string) []*regexp.Regexp {
+	expressions := make([]*regexp.Regexp, 0, len(globs))
+
+	for _, glob := range globs {
+		expressions = append(expressions, compileGlob(glob))
+	}
+
+	return expressions
+}
+
+// matchGlobs returns true when the file matches any of the compiled globs, using the file with slashes and without
+// the current directory prefix.
+func matchGlobs(expressions []*regexp.Regexp, file string) bool {
+	file = strings.TrimPrefix(strings.ReplaceAll(file, "\\", "/"), "./")
+
+	for _, expression := range expressions {
+		if expression.MatchString(file) {
+			return true
+		}
+	}
+
+	return false
+}
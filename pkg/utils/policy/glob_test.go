@@ -0,0 +1,47 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGlobs(t *testing.T) {
+	t.Run("should match the files by the glob", func(t *testing.T) {
+		testCases := []struct {
+			glob    string
+			file    string
+			matched bool
+		}{
+			{"**/*_test.go", "main_test.go", true},
+			{"**/*_test.go", "./internal/auth/auth_test.go", true},
+			{"**/*_test.go", "internal/auth/auth.go", false},
+			{"internal/**", "internal/auth/auth.go", true},
+			{"internal/**", "cmd/main.go", false},
+			{"*.go", "internal/main.go", false},
+			{"*.go", "main.go", true},
+			{"vendor/?.go", "vendor\\a.go", true},
+			{"(legacy)/*.go", "(legacy)/main.go", true},
+			{"docs/ações/*.md", "docs/ações/readme.md", true},
+		}
+
+		for _, testCase := range testCases {
+			assert.Equal(t, testCase.matched, matchGlobs(compileGlobs([]string{testCase.glob}), testCase.file),
+				testCase.glob, testCase.file)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"regexp"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/confidence"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/languages"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	validationUtils "github.com/Fotkurz/horusec-devkit/pkg/utils/validation"
+)
+
+// Policy is a quality gate, which fails when any of its rules is violated.
+type Policy struct {
+	Name  string  `json:"name" yaml:"name"`
+	Rules []*Rule `json:"rules" yaml:"rules"`
+}
+
+// Rule selects the vulnerabilities matching all of its filters, where an empty filter matches any value, and is
+// violated when more than MaxCount vulnerabilities are selected. File globs support "**" to match any directories
+// and are compiled by Validate, so changes into Files and ExcludeFiles require validating the rule again.
+// Example: {"name": "high go", "severities": ["HIGH"], "languages": ["Go"], "excludeTypes": ["Risk Accepted"],
+// "maxCount": 5}
+type Rule struct {
+	Name         string                  `json:"name" yaml:"name"`
+	Severities   []severities.Severity   `json:"severities,omitempty" yaml:"severities,omitempty"`
+	Confidences  []confidence.Confidence `json:"confidences,omitempty" yaml:"confidences,omitempty"`
+	Languages    []languages.Language    `json:"languages,omitempty" yaml:"languages,omitempty"`
+	Tools        []tools.Tool            `json:"tools,omitempty" yaml:"tools,omitempty"`
+	Types        []vulnerability.Type    `json:"types,omitempty" yaml:"types,omitempty"`
+	ExcludeTypes []vulnerability.Type    `json:"excludeTypes,omitempty" yaml:"excludeTypes,omitempty"`
+	Files        []string                `json:"files,omitempty" yaml:"files,omitempty"`
+	ExcludeFiles []string                `json:"excludeFiles,omitempty" yaml:"excludeFiles,omitempty"`
+	MaxCount     int                     `json:"maxCount" yaml:"maxCount"`
+
+	files        []*regexp.Regexp
+	excludeFiles []*regexp.Regexp
+}
+
+// ParsePolicy parses a policy written in JSON or YAML, returning error when it is invalid.
+// Usage example: policy, err := policy.ParsePolicy(data)
+func ParsePolicy(data []byte) (*Policy, error) {
+	policy := &Policy{}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, err
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// Validate returns the errors of all invalid fields, including the ones of each rule by its index.
+func (p *Policy) Validate() error {
+	return validation.ValidateStruct(p,
+		validation.Field(&p.Rules, validation.Required, validation.By(p.validateRules)),
+	)
+}
+
+func (p *Policy) validateRules(_ interface{}) error {
+	errs := validation.Errors{}
+
+	for index, rule := range p.Rules {
+		if err := rule.Validate(); err != nil {
+			errs[strconv.Itoa(index)] = err
+		}
+	}
+
+	return errs.Filter()
+}
+
+// Validate returns the errors of all invalid fields, also compiling the file globs used to match the vulnerabilities.
+func (r *Rule) Validate() error {
+	r.files = compileGlobs(r.Files)
+	r.excludeFiles = compileGlobs(r.ExcludeFiles)
+
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required),
+		validation.Field(&r.Severities, validation.Each(validationUtils.InValuesRule(severities.Values()))),
+		validation.Field(&r.Confidences, validation.Each(validationUtils.InValuesRule(confidence.Values()))),
+		validation.Field(&r.Languages, validation.Each(validationUtils.InValuesRule(languages.Values()))),
+		validation.Field(&r.Tools, validation.Each(validationUtils.InValuesRule(tools.Values()))),
+		validation.Field(&r.Types, validation.Each(validationUtils.InValuesRule(vulnerability.Values()))),
+		validation.Field(&r.ExcludeTypes, validation.Each(validationUtils.InValuesRule(vulnerability.Values()))),
+		validation.Field(&r.MaxCount, validation.Min(0)),
+	)
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/languages"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+)
+
+const testPolicyYAML = `
+name: default
+rules:
+  - name: critical leaks
+    severities: [CRITICAL]
+    languages: [Leaks]
+    excludeTypes: [Risk Accepted]
+  - name: high go
+    severities: [HIGH]
+    languages: [Go]
+    excludeFiles: ["**/*_test.go"]
+    maxCount: 5
+`
+
+func TestParsePolicy(t *testing.T) {
+	t.Run("should parse a yaml policy", func(t *testing.T) {
+		policy, err := ParsePolicy([]byte(testPolicyYAML))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "default", policy.Name)
+		assert.Equal(t, "critical leaks", policy.Rules[0].Name)
+		assert.Equal(t, []severities.Severity{severities.Critical}, policy.Rules[0].Severities)
+		assert.Equal(t, []languages.Language{languages.Leaks}, policy.Rules[0].Languages)
+		assert.Equal(t, []vulnerability.Type{vulnerability.RiskAccepted}, policy.Rules[0].ExcludeTypes)
+		assert.Equal(t, 5, policy.Rules[1].MaxCount)
+		assert.Equal(t, []string{"**/*_test.go"}, policy.Rules[1].ExcludeFiles)
+		assert.Len(t, policy.Rules[1].excludeFiles, 1)
+	})
+
+	t.Run("should parse a json policy", func(t *testing.T) {
+		policy, err := ParsePolicy([]byte(`{"name": "json", "rules": [{"name": "any", "tools": ["GoSec"]}]}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "any", policy.Rules[0].Name)
+	})
+
+	t.Run("should return error when invalid values", func(t *testing.T) {
+		_, err := ParsePolicy([]byte(`{"rules": [{"name": "any", "severities": ["SEVERE"], "maxCount": -1}]}`))
+
+		assert.EqualError(t, err,
+			"rules: (0: (maxCount: must be no less than 0; severities: (0: must be a valid value.).).).")
+	})
+
+	t.Run("should return error when no rules", func(t *testing.T) {
+		_, err := ParsePolicy([]byte(`{"name": "empty"}`))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when invalid syntax", func(t *testing.T) {
+		_, err := ParsePolicy([]byte(`rules: [`))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+const (
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	Version = "2.1.0"
+
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+	LevelNone    = "none"
+
+	KindPass          = "pass"
+	KindNotApplicable = "notApplicable"
+
+	FingerprintVulnHash = "horusecVulnHash/v1"
+
+	PropertySecuritySeverity = "security-severity"
+	PropertySeverity         = "severity"
+	PropertyConfidence       = "confidence"
+	PropertyType             = "type"
+	PropertyLanguage         = "language"
+	PropertyCommitAuthor     = "commitAuthor"
+	PropertyCommitEmail      = "commitEmail"
+	PropertyCommitHash       = "commitHash"
+	PropertyCommitMessage    = "commitMessage"
+	PropertyCommitDate       = "commitDate"
+
+	TagSecurity  = "security"
+	TagCWE       = "cwe"
+	TagCWEPrefix = "external/cwe/cwe-"
+)
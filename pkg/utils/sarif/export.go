@@ -0,0 +1,200 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"strconv"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cwe"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sarif/enums"
+)
+
+// NewReport converts the analysis into a SARIF report with one run per security tool, keeping the order in which the
+// tools first appear in the analysis vulnerabilities. The vulnerability hash is used as partial fingerprint, so the
+// code scanning tools are able to track the same vulnerability between analyses.
+// Usage example: _, err := file.Write(sarif.NewReport(analysis).ToBytes())
+func NewReport(entity *analysis.Analysis) *Report {
+	report := &Report{Schema: enums.Schema, Version: enums.Version, Runs: []*Run{}}
+	runs := map[string]*Run{}
+
+	for index := range entity.AnalysisVulnerabilities {
+		vuln := &entity.AnalysisVulnerabilities[index].Vulnerability
+
+		report.getRun(runs, vuln).addVulnerability(vuln)
+	}
+
+	return report
+}
+
+// getRun returns the run of the vulnerability security tool, adding a new one into the report when needed.
+func (r *Report) getRun(runs map[string]*Run, vuln *vulnerability.Vulnerability) *Run {
+	run, ok := runs[vuln.SecurityTool.ToString()]
+	if !ok {
+		run = newRun(vuln)
+		runs[vuln.SecurityTool.ToString()] = run
+		r.Runs = append(r.Runs, run)
+	}
+
+	return run
+}
+
+func newRun(vuln *vulnerability.Vulnerability) *Run {
+	return &Run{
+		Tool: Tool{Driver: Driver{
+			Name:           vuln.SecurityTool.ToString(),
+			Version:        vuln.SecurityToolVersion,
+			InformationURI: vuln.SecurityToolInfoURI,
+			Rules:          []*Rule{},
+		}},
+		Results: []*Result{},
+	}
+}
+
+func (r *Run) addVulnerability(vuln *vulnerability.Vulnerability) {
+	ruleID := getRuleID(vuln)
+
+	ruleIndex := r.getRuleIndex(ruleID)
+	if ruleIndex < 0 {
+		ruleIndex = len(r.Tool.Driver.Rules)
+		r.Tool.Driver.Rules = append(r.Tool.Driver.Rules, newRule(ruleID, vuln))
+	}
+
+	r.Results = append(r.Results, &Result{
+		RuleID:              ruleID,
+		RuleIndex:           &ruleIndex,
+		Level:               getLevel(vuln.Severity),
+		Message:             Message{Text: vuln.Details},
+		Locations:           []Location{newLocation(vuln)},
+		PartialFingerprints: getFingerprints(vuln),
+		Properties:          getResultProperties(vuln),
+	})
+}
+
+func (r *Run) getRuleIndex(ruleID string) int {
+	for index, rule := range r.Tool.Driver.Rules {
+		if rule.ID == ruleID {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// getRuleID returns the rule id of the vulnerability, or its first CWE when the tool has no rule ids, or the tool
+// name when it has none of them.
+func getRuleID(vuln *vulnerability.Vulnerability) string {
+	if vuln.RuleID != "" {
+		return vuln.RuleID
+	}
+
+	if cweIDs := cwe.ParseIDs(vuln.CWEs); len(cweIDs) > 0 {
+		return cwe.FormatID(cweIDs[0])
+	}
+
+	return vuln.SecurityTool.ToString()
+}
+
+func newRule(ruleID string, vuln *vulnerability.Vulnerability) *Rule {
+	tags := []string{enums.TagSecurity}
+	for _, cweID := range cwe.ParseIDs(vuln.CWEs) {
+		tags = append(tags, enums.TagCWEPrefix+strconv.Itoa(cweID))
+	}
+
+	return &Rule{
+		ID:               ruleID,
+		ShortDescription: &Message{Text: ruleID},
+		FullDescription:  &Message{Text: vuln.Details},
+		HelpURI:          vuln.Reference,
+		Properties: map[string]interface{}{
+			"tags":                         tags,
+			enums.PropertySecuritySeverity: getSecuritySeverity(vuln.Severity),
+		},
+	}
+}
+
+func newLocation(vuln *vulnerability.Vulnerability) Location {
+	location := Location{PhysicalLocation: PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: vuln.File}}}
+
+	line, _ := strconv.Atoi(vuln.Line)
+	if line <= 0 {
+		return location
+	}
+
+	column, _ := strconv.Atoi(vuln.Column)
+	location.PhysicalLocation.Region = &Region{StartLine: line, StartColumn: column}
+
+	if vuln.Code != "" {
+		location.PhysicalLocation.Region.Snippet = &Message{Text: vuln.Code}
+	}
+
+	return location
+}
+
+func getFingerprints(vuln *vulnerability.Vulnerability) map[string]string {
+	if vuln.VulnHash == "" {
+		return nil
+	}
+
+	return map[string]string{enums.FingerprintVulnHash: vuln.VulnHash}
+}
+
+func getResultProperties(vuln *vulnerability.Vulnerability) map[string]interface{} {
+	return map[string]interface{}{
+		enums.PropertySeverity:      vuln.Severity.ToString(),
+		enums.PropertyConfidence:    vuln.Confidence.ToString(),
+		enums.PropertyType:          vuln.Type.ToString(),
+		enums.PropertyLanguage:      string(vuln.Language),
+		enums.PropertyCommitAuthor:  vuln.CommitAuthor,
+		enums.PropertyCommitEmail:   vuln.CommitEmail,
+		enums.PropertyCommitHash:    vuln.CommitHash,
+		enums.PropertyCommitMessage: vuln.CommitMessage,
+		enums.PropertyCommitDate:    vuln.CommitDate,
+	}
+}
+
+func getLevel(severity severities.Severity) string {
+	levels := map[severities.Severity]string{
+		severities.Critical: enums.LevelError,
+		severities.High:     enums.LevelError,
+		severities.Medium:   enums.LevelWarning,
+		severities.Low:      enums.LevelNote,
+	}
+
+	if level, ok := levels[severity]; ok {
+		return level
+	}
+
+	return enums.LevelNone
+}
+
+// getSecuritySeverity returns the score used by code scanning tools to rank the rules, in the middle of the CVSS
+// range of each severity.
+func getSecuritySeverity(severity severities.Severity) string {
+	scores := map[severities.Severity]string{
+		severities.Critical: "9.5",
+		severities.High:     "8.0",
+		severities.Medium:   "5.5",
+		severities.Low:      "2.0",
+	}
+
+	if score, ok := scores[severity]; ok {
+		return score
+	}
+
+	return "0.0"
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/confidence"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/languages"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sarif/enums"
+)
+
+func getTestAnalysis() *analysis.Analysis {
+	return &analysis.Analysis{AnalysisVulnerabilities: []analysis.AnalysisVulnerabilities{
+		{Vulnerability: vulnerability.Vulnerability{RuleID: "HS-GO-1", SecurityTool: tools.HorusecEngine,
+			Severity: severities.High, Confidence: confidence.Medium, Type: vulnerabilityEnums.Vulnerability,
+			Language: languages.Go, File: "main.go", Line: "10", Column: "2", Code: "password := \"123\"",
+			Details: "hardcoded password", VulnHash: "hash1", SecurityToolVersion: "v2.0.0",
+			CWEs: []string{"https://cwe.mitre.org/data/definitions/798.html"}}},
+		{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.GoSec, Severity: severities.Low,
+			Confidence: confidence.Low, Type: vulnerabilityEnums.FalsePositive, File: "api.go", Line: "invalid",
+			Details: "unhandled error", CWEs: []string{"CWE-703"}}},
+		{Vulnerability: vulnerability.Vulnerability{RuleID: "HS-GO-1", SecurityTool: tools.HorusecEngine,
+			Severity: severities.High, Type: vulnerabilityEnums.Vulnerability, File: "other.go", Line: "3",
+			VulnHash: "hash2"}},
+	}}
+}
+
+func TestNewReport(t *testing.T) {
+	t.Run("should create one run per security tool", func(t *testing.T) {
+		report := NewReport(getTestAnalysis())
+
+		assert.Equal(t, enums.Version, report.Version)
+		assert.Equal(t, enums.Schema, report.Schema)
+		assert.Len(t, report.Runs, 2)
+		assert.Equal(t, tools.HorusecEngine.ToString(), report.Runs[0].Tool.Driver.Name)
+		assert.Equal(t, "v2.0.0", report.Runs[0].Tool.Driver.Version)
+		assert.Len(t, report.Runs[0].Results, 2)
+		assert.Len(t, report.Runs[0].Tool.Driver.Rules, 1)
+		assert.Equal(t, tools.GoSec.ToString(), report.Runs[1].Tool.Driver.Name)
+	})
+
+	t.Run("should map the vulnerability into a result", func(t *testing.T) {
+		result := NewReport(getTestAnalysis()).Runs[0].Results[0]
+
+		assert.Equal(t, "HS-GO-1", result.RuleID)
+		assert.Equal(t, 0, *result.RuleIndex)
+		assert.Equal(t, enums.LevelError, result.Level)
+		assert.Equal(t, "hardcoded password", result.Message.Text)
+		assert.Equal(t, "main.go", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, &Region{StartLine: 10, StartColumn: 2, Snippet: &Message{Text: "password := \"123\""}},
+			result.Locations[0].PhysicalLocation.Region)
+		assert.Equal(t, map[string]string{enums.FingerprintVulnHash: "hash1"}, result.PartialFingerprints)
+		assert.Equal(t, "Go", result.Properties[enums.PropertyLanguage])
+	})
+
+	t.Run("should map the cwes and the severity into the rule", func(t *testing.T) {
+		rule := NewReport(getTestAnalysis()).Runs[0].Tool.Driver.Rules[0]
+
+		assert.Equal(t, []string{enums.TagSecurity, "external/cwe/cwe-798"}, rule.Properties["tags"])
+		assert.Equal(t, "8.0", rule.Properties[enums.PropertySecuritySeverity])
+	})
+
+	t.Run("should use the cwe as rule id and omit invalid regions", func(t *testing.T) {
+		result := NewReport(getTestAnalysis()).Runs[1].Results[0]
+
+		assert.Equal(t, "CWE-703", result.RuleID)
+		assert.Equal(t, enums.LevelNote, result.Level)
+		assert.Nil(t, result.Locations[0].PhysicalLocation.Region)
+		assert.Nil(t, result.PartialFingerprints)
+	})
+
+	t.Run("should create an empty report when no vulnerabilities", func(t *testing.T) {
+		report := NewReport(&analysis.Analysis{})
+
+		assert.Empty(t, report.Runs)
+		assert.Contains(t, string(report.ToBytes()), `"runs": []`)
+	})
+}
+
+func TestGetRuleID(t *testing.T) {
+	t.Run("should return the tool name when no rule id and cwes", func(t *testing.T) {
+		assert.Equal(t, tools.Bandit.ToString(), getRuleID(&vulnerability.Vulnerability{SecurityTool: tools.Bandit}))
+	})
+}
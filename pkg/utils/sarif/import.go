@@ -0,0 +1,209 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	analysisEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/confidence"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/languages"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cvss"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cwe"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sarif/enums"
+)
+
+// ParseReport parses a SARIF 2.1.0 log, returning error when it is not a valid json or has another version.
+func ParseReport(data []byte) (*Report, error) {
+	report := &Report{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, err
+	}
+
+	if report.Version != enums.Version {
+		return nil, enums.ErrorUnsupportedVersion
+	}
+
+	return report, nil
+}
+
+// NewAnalysis builds a finished analysis from a SARIF log, which could be generated by any tool. Results that passed
+// or are not applicable are ignored. The Horusec properties written by NewReport are used when present, otherwise
+// the severity comes from the rule security severity or from the result level.
+// Usage example: analysis, err := sarif.NewAnalysis(data)
+func NewAnalysis(data []byte) (*analysis.Analysis, error) {
+	report, err := ParseReport(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return report.ToAnalysis(), nil
+}
+
+// ToAnalysis converts the report into a finished analysis, where each result is an analysis vulnerability.
+func (r *Report) ToAnalysis() *analysis.Analysis {
+	entity := &analysis.Analysis{
+		ID:                      uuid.New(),
+		Status:                  analysisEnums.Success,
+		CreatedAt:               time.Now(),
+		FinishedAt:              time.Now(),
+		AnalysisVulnerabilities: []analysis.AnalysisVulnerabilities{},
+	}
+
+	for _, run := range r.Runs {
+		for _, result := range run.Results {
+			if result.Kind == enums.KindPass || result.Kind == enums.KindNotApplicable {
+				continue
+			}
+
+			entity.AnalysisVulnerabilities = append(entity.AnalysisVulnerabilities,
+				analysis.AnalysisVulnerabilities{Vulnerability: *run.newVulnerability(result)})
+		}
+	}
+
+	entity.SetAllAnalysisVulnerabilitiesDefaultData()
+
+	return entity
+}
+
+func (r *Run) newVulnerability(result *Result) *vulnerability.Vulnerability {
+	rule := r.GetRule(result)
+	if rule == nil {
+		rule = &Rule{ID: result.RuleID}
+	}
+
+	vuln := &vulnerability.Vulnerability{RuleID: result.RuleID, Details: getDetails(result, rule),
+		Reference: rule.HelpURI, CWEs: getCWEs(rule), Severity: getSeverity(result, rule),
+		VulnHash: result.PartialFingerprints[enums.FingerprintVulnHash]}
+
+	r.setTool(vuln)
+	setLocation(vuln, result)
+	setProperties(vuln, result.Properties)
+
+	return vuln
+}
+
+func (r *Run) setTool(vuln *vulnerability.Vulnerability) {
+	vuln.SecurityTool = tools.Tool(r.Tool.Driver.Name)
+	vuln.SecurityToolVersion = r.Tool.Driver.Version
+	vuln.SecurityToolInfoURI = r.Tool.Driver.InformationURI
+}
+
+func getDetails(result *Result, rule *Rule) string {
+	if result.Message.Text != "" || rule.FullDescription == nil {
+		return result.Message.Text
+	}
+
+	return rule.FullDescription.Text
+}
+
+func getCWEs(rule *Rule) (cwes []string) {
+	tags, _ := rule.Properties["tags"].([]interface{})
+
+	for _, tag := range tags {
+		value, _ := tag.(string)
+		if cweID, ok := cwe.ParseID(value); ok && strings.Contains(strings.ToLower(value), enums.TagCWE) {
+			cwes = append(cwes, cwe.FormatURL(cweID))
+		}
+	}
+
+	return cwes
+}
+
+// getSeverity returns the severity of the Horusec properties, or the one matching the rule security severity, or
+// the one matching the result level, where an empty level is a warning as defined by the specification.
+func getSeverity(result *Result, rule *Rule) severities.Severity {
+	if severity := getStringProperty(result.Properties, enums.PropertySeverity); severities.Contains(severity) {
+		return severities.GetSeverityByString(strings.ToUpper(severity))
+	}
+
+	score, err := strconv.ParseFloat(getStringProperty(rule.Properties, enums.PropertySecuritySeverity), 64)
+	if err == nil {
+		return cvss.GetSeverity(score)
+	}
+
+	return getSeverityByLevel(result.Level)
+}
+
+func getSeverityByLevel(level string) severities.Severity {
+	levels := map[string]severities.Severity{
+		enums.LevelError: severities.High,
+		enums.LevelNote:  severities.Low,
+		enums.LevelNone:  severities.Info,
+	}
+
+	if severity, ok := levels[level]; ok {
+		return severity
+	}
+
+	return severities.Medium
+}
+
+func setLocation(vuln *vulnerability.Vulnerability, result *Result) {
+	if len(result.Locations) == 0 {
+		return
+	}
+
+	location := result.Locations[0].PhysicalLocation
+	vuln.File = location.ArtifactLocation.URI
+
+	if location.Region == nil {
+		return
+	}
+
+	vuln.Line = strconv.Itoa(location.Region.StartLine)
+	vuln.Column = strconv.Itoa(location.Region.StartColumn)
+
+	if location.Region.Snippet != nil {
+		vuln.Code = location.Region.Snippet.Text
+	}
+}
+
+func setProperties(vuln *vulnerability.Vulnerability, properties map[string]interface{}) {
+	vuln.Confidence = getConfidence(getStringProperty(properties, enums.PropertyConfidence))
+	vuln.Type = vulnerabilityEnums.GetVulnTypeOrDefault(getStringProperty(properties, enums.PropertyType))
+	vuln.Language = languages.ParseStringToLanguage(getStringProperty(properties, enums.PropertyLanguage))
+	vuln.CommitAuthor = getStringProperty(properties, enums.PropertyCommitAuthor)
+	vuln.CommitEmail = getStringProperty(properties, enums.PropertyCommitEmail)
+	vuln.CommitHash = getStringProperty(properties, enums.PropertyCommitHash)
+	vuln.CommitMessage = getStringProperty(properties, enums.PropertyCommitMessage)
+	vuln.CommitDate = getStringProperty(properties, enums.PropertyCommitDate)
+}
+
+func getConfidence(value string) confidence.Confidence {
+	for _, item := range confidence.Values() {
+		if strings.EqualFold(item.ToString(), value) {
+			return item
+		}
+	}
+
+	return confidence.Medium
+}
+
+func getStringProperty(properties map[string]interface{}, key string) string {
+	value, _ := properties[key].(string)
+
+	return value
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	analysisEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/confidence"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/languages"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sarif/enums"
+)
+
+const testThirdPartySARIF = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "Semgrep", "version": "1.0.0", "rules": [
+      {"id": "sql-injection", "fullDescription": {"text": "sql injection"}, "helpUri": "https://semgrep.dev",
+       "properties": {"tags": ["security", "CWE-89: SQL Injection"], "security-severity": "9.8"}},
+      {"id": "weak-hash"}
+    ]}},
+    "results": [
+      {"ruleId": "sql-injection", "message": {"text": ""}, "locations": [{"physicalLocation": {
+        "artifactLocation": {"uri": "db.py"}, "region": {"startLine": 7, "startColumn": 4,
+        "snippet": {"text": "cursor.execute(query)"}}}}]},
+      {"ruleId": "weak-hash", "level": "note", "message": {"text": "md5 usage"}},
+      {"ruleId": "weak-hash", "kind": "pass", "message": {"text": "md5 usage"}},
+      {"ruleId": "unknown", "message": {"text": "unknown rule"}}
+    ]
+  }]
+}`
+
+func TestNewAnalysis(t *testing.T) {
+	t.Run("should import a third party sarif", func(t *testing.T) {
+		entity, err := NewAnalysis([]byte(testThirdPartySARIF))
+
+		assert.NoError(t, err)
+		assert.Equal(t, analysisEnums.Success, entity.Status)
+		assert.Len(t, entity.AnalysisVulnerabilities, 3)
+
+		vuln := entity.AnalysisVulnerabilities[0].Vulnerability
+		assert.Equal(t, "sql-injection", vuln.RuleID)
+		assert.Equal(t, "Semgrep", vuln.SecurityTool.ToString())
+		assert.Equal(t, severities.Critical, vuln.Severity)
+		assert.Equal(t, confidence.Medium, vuln.Confidence)
+		assert.Equal(t, vulnerabilityEnums.Vulnerability, vuln.Type)
+		assert.Equal(t, languages.Unknown, vuln.Language)
+		assert.Equal(t, "sql injection", vuln.Details)
+		assert.Equal(t, []string{"https://cwe.mitre.org/data/definitions/89.html"}, vuln.CWEs)
+		assert.Equal(t, "db.py", vuln.File)
+		assert.Equal(t, "7", vuln.Line)
+		assert.Equal(t, "4", vuln.Column)
+		assert.Equal(t, "cursor.execute(query)", vuln.Code)
+		assert.Equal(t, entity.ID, entity.AnalysisVulnerabilities[0].AnalysisID)
+
+		assert.Equal(t, severities.Low, entity.AnalysisVulnerabilities[1].Vulnerability.Severity)
+		assert.Equal(t, severities.Medium, entity.AnalysisVulnerabilities[2].Vulnerability.Severity)
+	})
+
+	t.Run("should keep the vulnerabilities exported by horusec grouped by tool", func(t *testing.T) {
+		expected := getTestAnalysis()
+
+		entity, err := NewAnalysis(NewReport(expected).ToBytes())
+		assert.NoError(t, err)
+		assert.Len(t, entity.AnalysisVulnerabilities, 3)
+
+		for index, expectedIndex := range []int{0, 2, 1} {
+			want := expected.AnalysisVulnerabilities[expectedIndex].Vulnerability
+			got := entity.AnalysisVulnerabilities[index].Vulnerability
+
+			assert.Equal(t, want.Severity, got.Severity)
+			assert.Equal(t, want.Type, got.Type)
+			assert.Equal(t, want.File, got.File)
+			assert.Equal(t, want.VulnHash, got.VulnHash)
+			assert.Equal(t, want.SecurityTool, got.SecurityTool)
+		}
+
+		vuln := entity.AnalysisVulnerabilities[0].Vulnerability
+		assert.Equal(t, confidence.Medium, vuln.Confidence)
+		assert.Equal(t, languages.Go, vuln.Language)
+		assert.Equal(t, "HS-GO-1", vuln.RuleID)
+		assert.Equal(t, "10", vuln.Line)
+		assert.Equal(t, []string{"https://cwe.mitre.org/data/definitions/798.html"}, vuln.CWEs)
+	})
+
+	t.Run("should return error when unsupported version", func(t *testing.T) {
+		_, err := NewAnalysis([]byte(`{"version": "2.0.0", "runs": []}`))
+
+		assert.ErrorIs(t, err, enums.ErrorUnsupportedVersion)
+	})
+
+	t.Run("should return error when invalid json", func(t *testing.T) {
+		_, err := NewAnalysis([]byte("invalid"))
+
+		assert.Error(t, err)
+	})
+}
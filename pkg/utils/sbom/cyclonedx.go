@@ -0,0 +1,279 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cve"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cvss"
+	cvssEnums "github.com/Fotkurz/horusec-devkit/pkg/utils/cvss/enums"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cwe"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sbom/enums"
+)
+
+// CycloneDX is a CycloneDX 1.4 VEX document, containing only the properties used by the Horusec export. The complete
+// specification is available at https://cyclonedx.org/docs/1.4/json
+type CycloneDX struct {
+	Schema          string           `json:"$schema"`
+	BOMFormat       string           `json:"bomFormat"`
+	SpecVersion     string           `json:"specVersion"`
+	SerialNumber    string           `json:"serialNumber,omitempty"`
+	Version         int              `json:"version"`
+	Metadata        Metadata         `json:"metadata"`
+	Components      []*Component     `json:"components"`
+	Vulnerabilities []*Vulnerability `json:"vulnerabilities"`
+}
+
+type Metadata struct {
+	Timestamp string         `json:"timestamp"`
+	Tools     []MetadataTool `json:"tools"`
+	Component *Component     `json:"component,omitempty"`
+}
+
+type MetadataTool struct {
+	Vendor string `json:"vendor"`
+	Name   string `json:"name"`
+}
+
+type Component struct {
+	Type    string `json:"type"`
+	BOMRef  string `json:"bom-ref"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl,omitempty"`
+}
+
+// Vulnerability is a vulnerability of one or more components with the same analysis state.
+type Vulnerability struct {
+	BOMRef         string                 `json:"bom-ref"`
+	ID             string                 `json:"id"`
+	Source         *Source                `json:"source,omitempty"`
+	Ratings        []Rating               `json:"ratings"`
+	CWEs           []int                  `json:"cwes,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	Recommendation string                 `json:"recommendation,omitempty"`
+	Advisories     []Advisory             `json:"advisories,omitempty"`
+	Analysis       *VulnerabilityAnalysis `json:"analysis"`
+	Affects        []Affect               `json:"affects"`
+	Properties     []Property             `json:"properties,omitempty"`
+}
+
+type Source struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type Rating struct {
+	Score    float64 `json:"score,omitempty"`
+	Severity string  `json:"severity"`
+	Method   string  `json:"method"`
+	Vector   string  `json:"vector,omitempty"`
+}
+
+type Advisory struct {
+	URL string `json:"url"`
+}
+
+type VulnerabilityAnalysis struct {
+	State    string   `json:"state"`
+	Response []string `json:"response,omitempty"`
+}
+
+type Affect struct {
+	Ref string `json:"ref"`
+}
+
+type Property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// NewCycloneDX creates a CycloneDX VEX document with the vulnerabilities found by the dependency tools of the analysis.
+// Each vulnerable dependency is added as a component, and the vulnerability type is mapped to the analysis state:
+// vulnerabilities not triaged yet are in triage, accepted risks are exploitable and will not be fixed, false
+// positives are false positives and corrected ones are resolved.
+// Usage example: bytes := sbom.NewCycloneDX(analysis).ToBytes()
+func NewCycloneDX(entity *analysis.Analysis) *CycloneDX {
+	bom := &CycloneDX{
+		Schema:          enums.CycloneDXSchema,
+		BOMFormat:       enums.CycloneDXFormat,
+		SpecVersion:     enums.CycloneDXSpecVersion,
+		SerialNumber:    getSerialNumber(entity.ID),
+		Version:         1,
+		Metadata:        newMetadata(entity),
+		Components:      []*Component{},
+		Vulnerabilities: []*Vulnerability{},
+	}
+
+	bom.addFindings(getFindings(entity))
+
+	return bom
+}
+
+func (c *CycloneDX) ToBytes() []byte {
+	bytes, _ := json.MarshalIndent(c, "", "  ")
+
+	return bytes
+}
+
+func (c *CycloneDX) addFindings(findings []*finding) {
+	components := map[string]bool{}
+	vulnerabilities := map[string]*Vulnerability{}
+
+	for _, vulnFinding := range findings {
+		if !components[vulnFinding.component.BOMRef] {
+			components[vulnFinding.component.BOMRef] = true
+			c.Components = append(c.Components, vulnFinding.component)
+		}
+
+		for _, id := range vulnFinding.ids {
+			c.getVulnerability(vulnerabilities, id, vulnFinding).addAffect(vulnFinding.component.BOMRef)
+		}
+	}
+}
+
+// getVulnerability returns the vulnerability with the same id and state of the finding, creating it when not found,
+// so the components affected by the same vulnerability are grouped.
+func (c *CycloneDX) getVulnerability(vulnerabilities map[string]*Vulnerability, id string,
+	vulnFinding *finding) *Vulnerability {
+	vulnState := getState(vulnFinding.vuln.Type)
+	bomRef := fmt.Sprintf("%s/%s", id, vulnState.name)
+
+	vuln, ok := vulnerabilities[bomRef]
+	if !ok {
+		vuln = newVulnerability(bomRef, id, vulnFinding)
+		vuln.Analysis = &VulnerabilityAnalysis{State: vulnState.name, Response: vulnState.response}
+		vulnerabilities[bomRef] = vuln
+		c.Vulnerabilities = append(c.Vulnerabilities, vuln)
+	}
+
+	return vuln
+}
+
+func newVulnerability(bomRef, id string, vulnFinding *finding) *Vulnerability {
+	return &Vulnerability{
+		BOMRef:         bomRef,
+		ID:             id,
+		Source:         newSource(id, vulnFinding),
+		Ratings:        []Rating{newRating(vulnFinding)},
+		CWEs:           cwe.ParseIDs(vulnFinding.vuln.CWEs),
+		Description:    vulnFinding.vuln.Details,
+		Recommendation: vulnFinding.vuln.Mitigation,
+		Advisories:     getAdvisories(vulnFinding),
+		Affects:        []Affect{},
+		Properties:     getProperties(vulnFinding),
+	}
+}
+
+func getProperties(vulnFinding *finding) []Property {
+	return []Property{
+		{Name: enums.PropertySecurityTool, Value: vulnFinding.vuln.SecurityTool.ToString()},
+		{Name: enums.PropertyType, Value: vulnFinding.vuln.Type.ToString()},
+	}
+}
+
+func (v *Vulnerability) addAffect(bomRef string) {
+	for _, affect := range v.Affects {
+		if affect.Ref == bomRef {
+			return
+		}
+	}
+
+	v.Affects = append(v.Affects, Affect{Ref: bomRef})
+}
+
+func newMetadata(entity *analysis.Analysis) Metadata {
+	metadata := Metadata{
+		Timestamp: getTimestamp(entity),
+		Tools:     []MetadataTool{{Vendor: enums.ToolVendor, Name: enums.ToolName}},
+	}
+
+	if entity.RepositoryName != "" {
+		metadata.Component = &Component{
+			Type:   enums.ComponentTypeApplication,
+			BOMRef: entity.RepositoryName,
+			Name:   entity.RepositoryName,
+		}
+	}
+
+	return metadata
+}
+
+func newSource(id string, vulnFinding *finding) *Source {
+	if cve.IsID(id) {
+		return &Source{Name: enums.SourceNVD, URL: cve.FormatURL(id)}
+	}
+
+	return &Source{Name: vulnFinding.vuln.SecurityTool.ToString(), URL: vulnFinding.vuln.SecurityToolInfoURI}
+}
+
+func getAdvisories(vulnFinding *finding) []Advisory {
+	if vulnFinding.vuln.Reference == "" {
+		return nil
+	}
+
+	return []Advisory{{URL: vulnFinding.vuln.Reference}}
+}
+
+// newRating returns the rating of the CVSS vector with its base score when valid, otherwise the rating of the
+// vulnerability severity, which includes the CVSS 4.0 vectors that are not supported by the cvss package.
+func newRating(vulnFinding *finding) Rating {
+	rating := Rating{Severity: getSeverity(vulnFinding), Method: enums.RatingMethodOther}
+
+	vector, err := cvss.Parse(vulnFinding.vuln.CVSSVector)
+	if err != nil {
+		return rating
+	}
+
+	scores, err := vector.GetScores()
+	if err != nil {
+		return rating
+	}
+
+	return Rating{Score: scores.Base, Severity: strings.ToLower(cvss.GetSeverity(scores.Base).ToString()),
+		Method: getRatingMethod(vector.Version), Vector: vulnFinding.vuln.CVSSVector}
+}
+
+func getRatingMethod(version string) string {
+	methods := map[string]string{
+		cvssEnums.Version30: enums.RatingMethodCVSSv3,
+		cvssEnums.Version31: enums.RatingMethodCVSSv31,
+	}
+
+	return methods[version]
+}
+
+func getSeverity(vulnFinding *finding) string {
+	if vulnFinding.vuln.Severity == "" {
+		return strings.ToLower(severities.Unknown.ToString())
+	}
+
+	return strings.ToLower(vulnFinding.vuln.Severity.ToString())
+}
+
+func getSerialNumber(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+
+	return fmt.Sprintf(enums.CycloneDXSerial, id)
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sbom/enums"
+)
+
+func TestNewCycloneDX(t *testing.T) {
+	t.Run("should create a vex document with the dependency vulnerabilities", func(t *testing.T) {
+		entity := getTestAnalysis()
+		entity.ID = uuid.New()
+
+		bom := NewCycloneDX(entity)
+
+		assert.Equal(t, enums.CycloneDXFormat, bom.BOMFormat)
+		assert.Equal(t, enums.CycloneDXSpecVersion, bom.SpecVersion)
+		assert.Equal(t, "urn:uuid:"+entity.ID.String(), bom.SerialNumber)
+		assert.Equal(t, "2021-12-30T23:59:59Z", bom.Metadata.Timestamp)
+		assert.Equal(t, "my-project", bom.Metadata.Component.Name)
+		assert.Len(t, bom.Components, 2)
+		assert.Len(t, bom.Vulnerabilities, 2)
+	})
+
+	t.Run("should group the components affected by the same vulnerability", func(t *testing.T) {
+		vuln := NewCycloneDX(getTestAnalysis()).Vulnerabilities[0]
+
+		assert.Equal(t, "CVE-2021-23337", vuln.ID)
+		assert.Equal(t, "CVE-2021-23337/in_triage", vuln.BOMRef)
+		assert.Equal(t, &Source{Name: enums.SourceNVD, URL: "https://nvd.nist.gov/vuln/detail/CVE-2021-23337"},
+			vuln.Source)
+		assert.Equal(t, []Rating{{Severity: "high", Method: enums.RatingMethodOther}}, vuln.Ratings)
+		assert.Equal(t, []int{77}, vuln.CWEs)
+		assert.Equal(t, &VulnerabilityAnalysis{State: enums.StateInTriage}, vuln.Analysis)
+		assert.Equal(t, []Affect{{Ref: "pkg:npm/lodash@4.17.20"}}, vuln.Affects)
+	})
+
+	t.Run("should map the vulnerability type to the analysis state", func(t *testing.T) {
+		vuln := NewCycloneDX(getTestAnalysis()).Vulnerabilities[1]
+
+		assert.Equal(t, "CVE-2021-35042", vuln.ID)
+		assert.Equal(t, &VulnerabilityAnalysis{State: enums.StateFalsePositive}, vuln.Analysis)
+		assert.Equal(t, []Affect{{Ref: "pkg:pypi/django@3.1"}}, vuln.Affects)
+	})
+
+	t.Run("should use the security tool as source when no cve", func(t *testing.T) {
+		entity := getTestAnalysis()
+		entity.AnalysisVulnerabilities[0].Vulnerability.CVEs = nil
+		entity.AnalysisVulnerabilities[0].Vulnerability.RuleID = "GHSA-35jh-r3h4-6jhm"
+		entity.AnalysisVulnerabilities[0].Vulnerability.Severity = ""
+
+		vuln := NewCycloneDX(entity).Vulnerabilities[0]
+
+		assert.Equal(t, "GHSA-35jh-r3h4-6jhm", vuln.ID)
+		assert.Equal(t, "NpmAudit", vuln.Source.Name)
+		assert.Equal(t, "unknown", vuln.Ratings[0].Severity)
+	})
+
+	t.Run("should rate the vulnerability by the cvss vector", func(t *testing.T) {
+		entity := getTestAnalysis()
+		entity.AnalysisVulnerabilities[0].Vulnerability.CVSSVector = "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"
+		entity.AnalysisVulnerabilities[2].Vulnerability.CVSSVector =
+			"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N"
+
+		bom := NewCycloneDX(entity)
+
+		assert.Equal(t, []Rating{{Score: 9.8, Severity: "critical", Method: enums.RatingMethodCVSSv31,
+			Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}}, bom.Vulnerabilities[0].Ratings)
+		assert.Equal(t, []Rating{{Severity: "medium", Method: enums.RatingMethodOther}}, bom.Vulnerabilities[1].Ratings)
+	})
+
+	t.Run("should create an empty document when no dependency vulnerabilities", func(t *testing.T) {
+		bom := NewCycloneDX(&analysis.Analysis{})
+
+		assert.Empty(t, bom.SerialNumber)
+		assert.Nil(t, bom.Metadata.Component)
+		assert.NotEmpty(t, bom.Metadata.Timestamp)
+		assert.Empty(t, bom.Components)
+		assert.Empty(t, bom.Vulnerabilities)
+	})
+}
+
+func TestCycloneDXToBytes(t *testing.T) {
+	t.Run("should marshal the document to json", func(t *testing.T) {
+		bom := &CycloneDX{}
+
+		assert.NoError(t, json.Unmarshal(NewCycloneDX(getTestAnalysis()).ToBytes(), bom))
+		assert.Equal(t, NewCycloneDX(getTestAnalysis()), bom)
+	})
+}
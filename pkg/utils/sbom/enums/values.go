@@ -0,0 +1,63 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enums
+
+const (
+	CycloneDXFormat      = "CycloneDX"
+	CycloneDXSpecVersion = "1.4"
+	CycloneDXSchema      = "http://cyclonedx.org/schema/bom-1.4.schema.json"
+	CycloneDXSerial      = "urn:uuid:%s"
+
+	ComponentTypeLibrary     = "library"
+	ComponentTypeApplication = "application"
+
+	StateInTriage      = "in_triage"
+	StateExploitable   = "exploitable"
+	StateFalsePositive = "false_positive"
+	StateResolved      = "resolved"
+
+	ResponseWillNotFix = "will_not_fix"
+	ResponseUpdate     = "update"
+
+	RatingMethodOther    = "other"
+	RatingMethodCVSSv3   = "CVSSv3"
+	RatingMethodCVSSv31  = "CVSSv31"
+	PropertySecurityTool = "horusec:securityTool"
+	PropertyType         = "horusec:type"
+
+	SPDXVersion           = "SPDX-2.3"
+	SPDXDataLicense       = "CC0-1.0"
+	SPDXDocumentID        = "SPDXRef-DOCUMENT"
+	SPDXPackageID         = "SPDXRef-Package-%d"
+	SPDXNamespace         = "https://horusec.io/spdx/%s"
+	SPDXNoAssertion       = "NOASSERTION"
+	SPDXRelationship      = "DESCRIBES"
+	SPDXAnnotationType    = "REVIEW"
+	SPDXAnnotationComment = "%s %s: %s"
+
+	RefCategorySecurity = "SECURITY"
+	RefCategoryPackage  = "PACKAGE-MANAGER"
+	RefTypeAdvisory     = "advisory"
+	RefTypePURL         = "purl"
+
+	PURLFormat      = "pkg:%s/%s"
+	PURLTypeGeneric = "generic"
+
+	ToolVendor  = "Horusec"
+	ToolName    = "horusec"
+	ToolCreator = "Tool: horusec"
+
+	SourceNVD = "NVD"
+)
@@ -0,0 +1,154 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cve"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sbom/enums"
+)
+
+// finding is a vulnerability reported by a dependency tool, with the affected component and the vulnerability ids
+// parsed from it.
+type finding struct {
+	vuln      *vulnerability.Vulnerability
+	component *Component
+	ids       []string
+}
+
+// state is the VEX analysis of a finding, mapped from the vulnerability type set during the triage.
+type state struct {
+	name     string
+	response []string
+}
+
+// getFindings returns the findings of all dependency tools, ignoring the vulnerabilities found on the source code.
+func getFindings(entity *analysis.Analysis) (findings []*finding) {
+	for index := range entity.AnalysisVulnerabilities {
+		vuln := &entity.AnalysisVulnerabilities[index].Vulnerability
+		if !vuln.SecurityTool.IsDependencyTool() {
+			continue
+		}
+
+		findings = append(findings, &finding{vuln: vuln, component: newComponent(vuln), ids: getIDs(vuln)})
+	}
+
+	return findings
+}
+
+// newComponent parses the dependency name and version from the vulnerability code, which is filled by the dependency
+// tools with values like "lodash", "lodash@4.17.20", "django==3.1" or "openssl 1.1.1". When empty, the vulnerable
+// file is used as component name.
+func newComponent(vuln *vulnerability.Vulnerability) *Component {
+	name, version := parseDependency(vuln.Code)
+	if name == "" {
+		name = vuln.File
+	}
+
+	purl := fmt.Sprintf(enums.PURLFormat, getPURLType(vuln.SecurityTool), escapePURLName(name))
+	if version != "" {
+		purl += "@" + version
+	}
+
+	return &Component{Type: enums.ComponentTypeLibrary, BOMRef: purl, Name: name, Version: version, PURL: purl}
+}
+
+func parseDependency(code string) (name, version string) {
+	code = strings.TrimSpace(strings.SplitN(strings.TrimSpace(code), "\n", 2)[0])
+
+	if index := strings.Index(code, "=="); index > 0 {
+		return code[:index], code[index+2:]
+	}
+
+	if index := strings.LastIndex(code, "@"); index > 0 {
+		return code[:index], code[index+1:]
+	}
+
+	if fields := strings.Fields(code); len(fields) > 1 {
+		return fields[0], fields[1]
+	}
+
+	return code, ""
+}
+
+func getPURLType(tool tools.Tool) string {
+	purlTypes := map[tools.Tool]string{
+		tools.NpmAudit:             "npm",
+		tools.YarnAudit:            "npm",
+		tools.Safety:               "pypi",
+		tools.BundlerAudit:         "gem",
+		tools.OwaspDependencyCheck: "maven",
+		tools.Nancy:                "golang",
+	}
+
+	if purlType, ok := purlTypes[tool]; ok {
+		return purlType
+	}
+
+	return enums.PURLTypeGeneric
+}
+
+func escapePURLName(name string) string {
+	return strings.NewReplacer("@", "%40", ":", "/", " ", "%20").Replace(name)
+}
+
+// getIDs returns the CVE ids of the vulnerability, searching on the details when the CVEs field is empty. When no CVE
+// is found, the rule id or the vulnerability hash is used as id.
+func getIDs(vuln *vulnerability.Vulnerability) []string {
+	ids := cve.ParseIDs(strings.Join(vuln.CVEs, " "))
+	if len(ids) == 0 {
+		ids = cve.ParseIDs(vuln.Details)
+	}
+
+	if len(ids) > 0 {
+		return ids
+	}
+
+	if vuln.RuleID != "" {
+		return []string{vuln.RuleID}
+	}
+
+	return []string{vuln.VulnHash}
+}
+
+func getState(vulnType vulnerabilityEnums.Type) state {
+	states := map[vulnerabilityEnums.Type]state{
+		vulnerabilityEnums.RiskAccepted:  {name: enums.StateExploitable, response: []string{enums.ResponseWillNotFix}},
+		vulnerabilityEnums.FalsePositive: {name: enums.StateFalsePositive},
+		vulnerabilityEnums.Corrected:     {name: enums.StateResolved, response: []string{enums.ResponseUpdate}},
+	}
+
+	if vulnState, ok := states[vulnType]; ok {
+		return vulnState
+	}
+
+	return state{name: enums.StateInTriage}
+}
+
+// getTimestamp returns when the analysis finished, or the current time for analysis that are not finished yet.
+func getTimestamp(entity *analysis.Analysis) string {
+	if entity.FinishedAt.IsZero() {
+		return time.Now().UTC().Format(time.RFC3339)
+	}
+
+	return entity.FinishedAt.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/tools"
+	vulnerabilityEnums "github.com/Fotkurz/horusec-devkit/pkg/enums/vulnerability"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sbom/enums"
+)
+
+func getTestAnalysis() *analysis.Analysis {
+	return &analysis.Analysis{
+		RepositoryName: "my-project",
+		FinishedAt:     time.Date(2021, 12, 30, 23, 59, 59, 0, time.UTC),
+		AnalysisVulnerabilities: []analysis.AnalysisVulnerabilities{
+			{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.NpmAudit, Code: "lodash@4.17.20",
+				Severity: severities.High, Type: vulnerabilityEnums.Vulnerability, File: "package-lock.json",
+				Details: "Command Injection", CVEs: []string{"CVE-2021-23337"}, CWEs: []string{"CWE-77"}}},
+			{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.YarnAudit, Code: "lodash@4.17.20",
+				Severity: severities.High, Type: vulnerabilityEnums.Vulnerability, File: "yarn.lock",
+				Details: "Command Injection (CVE-2021-23337)"}},
+			{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.Safety, Code: "django==3.1",
+				Severity: severities.Medium, Type: vulnerabilityEnums.FalsePositive, File: "requirements.txt",
+				Details: "SQL injection", CVEs: []string{"https://nvd.nist.gov/vuln/detail/CVE-2021-35042"}}},
+			{Vulnerability: vulnerability.Vulnerability{SecurityTool: tools.HorusecEngine, Code: "password := \"1\"",
+				Severity: severities.High, Type: vulnerabilityEnums.Vulnerability, File: "main.go"}},
+		},
+	}
+}
+
+func TestGetFindings(t *testing.T) {
+	t.Run("should return only the vulnerabilities of dependency tools", func(t *testing.T) {
+		findings := getFindings(getTestAnalysis())
+
+		assert.Len(t, findings, 3)
+		assert.Equal(t, []string{"CVE-2021-23337"}, findings[0].ids)
+		assert.Equal(t, []string{"CVE-2021-23337"}, findings[1].ids)
+		assert.Equal(t, []string{"CVE-2021-35042"}, findings[2].ids)
+	})
+}
+
+func TestNewComponent(t *testing.T) {
+	t.Run("should parse the dependency name and version", func(t *testing.T) {
+		testCases := []struct {
+			vuln    vulnerability.Vulnerability
+			name    string
+			version string
+			purl    string
+		}{
+			{vulnerability.Vulnerability{SecurityTool: tools.NpmAudit, Code: "@babel/core@7.0.0"},
+				"@babel/core", "7.0.0", "pkg:npm/%40babel/core@7.0.0"},
+			{vulnerability.Vulnerability{SecurityTool: tools.Safety, Code: "django==3.1\nother line"},
+				"django", "3.1", "pkg:pypi/django@3.1"},
+			{vulnerability.Vulnerability{SecurityTool: tools.Trivy, Code: "openssl 1.1.1"},
+				"openssl", "1.1.1", "pkg:generic/openssl@1.1.1"},
+			{vulnerability.Vulnerability{SecurityTool: tools.OwaspDependencyCheck, Code: "org.apache:log4j"},
+				"org.apache:log4j", "", "pkg:maven/org.apache/log4j"},
+			{vulnerability.Vulnerability{SecurityTool: tools.Nancy, File: "go.sum"},
+				"go.sum", "", "pkg:golang/go.sum"},
+		}
+
+		for _, testCase := range testCases {
+			component := newComponent(&testCase.vuln)
+
+			assert.Equal(t, enums.ComponentTypeLibrary, component.Type)
+			assert.Equal(t, testCase.name, component.Name)
+			assert.Equal(t, testCase.version, component.Version)
+			assert.Equal(t, testCase.purl, component.PURL)
+			assert.Equal(t, testCase.purl, component.BOMRef)
+		}
+	})
+}
+
+func TestGetIDs(t *testing.T) {
+	t.Run("should return the rule id when no cve", func(t *testing.T) {
+		assert.Equal(t, []string{"GHSA-1"}, getIDs(&vulnerability.Vulnerability{RuleID: "GHSA-1"}))
+	})
+
+	t.Run("should return the vulnerability hash when no cve and rule id", func(t *testing.T) {
+		assert.Equal(t, []string{"hash"}, getIDs(&vulnerability.Vulnerability{VulnHash: "hash"}))
+	})
+
+	t.Run("should return all cves without duplications", func(t *testing.T) {
+		ids := getIDs(&vulnerability.Vulnerability{CVEs: []string{"cve-2021-1111", "CVE-2021-1111", "CVE-2020-22222"}})
+
+		assert.Equal(t, []string{"CVE-2021-1111", "CVE-2020-22222"}, ids)
+	})
+}
+
+func TestGetState(t *testing.T) {
+	t.Run("should map the vulnerability type to the vex state", func(t *testing.T) {
+		assert.Equal(t, state{name: enums.StateInTriage}, getState(vulnerabilityEnums.Vulnerability))
+		assert.Equal(t, state{name: enums.StateExploitable, response: []string{enums.ResponseWillNotFix}},
+			getState(vulnerabilityEnums.RiskAccepted))
+		assert.Equal(t, state{name: enums.StateFalsePositive}, getState(vulnerabilityEnums.FalsePositive))
+		assert.Equal(t, state{name: enums.StateResolved, response: []string{enums.ResponseUpdate}},
+			getState(vulnerabilityEnums.Corrected))
+	})
+}
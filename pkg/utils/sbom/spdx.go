@@ -0,0 +1,179 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/cve"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sbom/enums"
+)
+
+// SPDX is a SPDX 2.3 document, containing only the properties used by the Horusec export. The SPDX 2.3 does not have
+// a vulnerability section, so the vulnerabilities are added as security references and review annotations of the
+// affected packages. The complete specification is available at https://spdx.github.io/spdx-spec/v2.3
+type SPDX struct {
+	SPDXVersion       string         `json:"spdxVersion"`
+	DataLicense       string         `json:"dataLicense"`
+	SPDXID            string         `json:"SPDXID"`
+	Name              string         `json:"name"`
+	DocumentNamespace string         `json:"documentNamespace"`
+	CreationInfo      CreationInfo   `json:"creationInfo"`
+	Packages          []*Package     `json:"packages"`
+	Relationships     []Relationship `json:"relationships"`
+}
+
+type CreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type Package struct {
+	SPDXID           string        `json:"SPDXID"`
+	Name             string        `json:"name"`
+	VersionInfo      string        `json:"versionInfo,omitempty"`
+	DownloadLocation string        `json:"downloadLocation"`
+	FilesAnalyzed    bool          `json:"filesAnalyzed"`
+	ExternalRefs     []ExternalRef `json:"externalRefs"`
+	Annotations      []Annotation  `json:"annotations,omitempty"`
+}
+
+type ExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type Annotation struct {
+	AnnotationDate string `json:"annotationDate"`
+	AnnotationType string `json:"annotationType"`
+	Annotator      string `json:"annotator"`
+	Comment        string `json:"comment"`
+}
+
+type Relationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// NewSPDX creates a SPDX document describing the dependencies found vulnerable by the dependency tools of the
+// analysis. Each CVE is added as a security advisory reference of the package, and each vulnerability as an annotation
+// with its id, state and details, using the same states of the CycloneDX export.
+// Usage example: bytes := sbom.NewSPDX(analysis).ToBytes()
+func NewSPDX(entity *analysis.Analysis) *SPDX {
+	document := &SPDX{
+		SPDXVersion:       enums.SPDXVersion,
+		DataLicense:       enums.SPDXDataLicense,
+		SPDXID:            enums.SPDXDocumentID,
+		Name:              entity.RepositoryName,
+		DocumentNamespace: getDocumentNamespace(entity.ID),
+		CreationInfo:      CreationInfo{Created: getTimestamp(entity), Creators: []string{enums.ToolCreator}},
+		Packages:          []*Package{},
+		Relationships:     []Relationship{},
+	}
+
+	document.addFindings(getFindings(entity), getTimestamp(entity))
+
+	return document
+}
+
+func (s *SPDX) ToBytes() []byte {
+	bytes, _ := json.MarshalIndent(s, "", "  ")
+
+	return bytes
+}
+
+func (s *SPDX) addFindings(findings []*finding, timestamp string) {
+	packages := map[string]*Package{}
+
+	for _, vulnFinding := range findings {
+		spdxPackage := s.getPackage(packages, vulnFinding.component)
+
+		for _, id := range vulnFinding.ids {
+			spdxPackage.addVulnerability(id, vulnFinding, timestamp)
+		}
+	}
+}
+
+func (s *SPDX) getPackage(packages map[string]*Package, component *Component) *Package {
+	spdxPackage, ok := packages[component.BOMRef]
+	if !ok {
+		spdxPackage = newPackage(fmt.Sprintf(enums.SPDXPackageID, len(s.Packages)+1), component)
+		packages[component.BOMRef] = spdxPackage
+		s.Packages = append(s.Packages, spdxPackage)
+		s.Relationships = append(s.Relationships, Relationship{
+			SPDXElementID:      enums.SPDXDocumentID,
+			RelationshipType:   enums.SPDXRelationship,
+			RelatedSPDXElement: spdxPackage.SPDXID,
+		})
+	}
+
+	return spdxPackage
+}
+
+func newPackage(id string, component *Component) *Package {
+	return &Package{
+		SPDXID:           id,
+		Name:             component.Name,
+		VersionInfo:      component.Version,
+		DownloadLocation: enums.SPDXNoAssertion,
+		ExternalRefs: []ExternalRef{{
+			ReferenceCategory: enums.RefCategoryPackage,
+			ReferenceType:     enums.RefTypePURL,
+			ReferenceLocator:  component.PURL,
+		}},
+	}
+}
+
+func (p *Package) addVulnerability(id string, vulnFinding *finding, timestamp string) {
+	if cve.IsID(id) {
+		p.addAdvisory(cve.FormatURL(id))
+	}
+
+	p.Annotations = append(p.Annotations, Annotation{
+		AnnotationDate: timestamp,
+		AnnotationType: enums.SPDXAnnotationType,
+		Annotator:      enums.ToolCreator,
+		Comment: fmt.Sprintf(enums.SPDXAnnotationComment, id, getState(vulnFinding.vuln.Type).name,
+			vulnFinding.vuln.Details),
+	})
+}
+
+func (p *Package) addAdvisory(url string) {
+	for _, ref := range p.ExternalRefs {
+		if ref.ReferenceLocator == url {
+			return
+		}
+	}
+
+	p.ExternalRefs = append(p.ExternalRefs, ExternalRef{
+		ReferenceCategory: enums.RefCategorySecurity,
+		ReferenceType:     enums.RefTypeAdvisory,
+		ReferenceLocator:  url,
+	})
+}
+
+func getDocumentNamespace(id uuid.UUID) string {
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	return fmt.Sprintf(enums.SPDXNamespace, id)
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 ZUP IT SERVICOS EM TECNOLOGIA E INOVACAO SA
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Fotkurz/horusec-devkit/pkg/entities/analysis"
+	"github.com/Fotkurz/horusec-devkit/pkg/utils/sbom/enums"
+)
+
+func TestNewSPDX(t *testing.T) {
+	t.Run("should create a document describing the vulnerable dependencies", func(t *testing.T) {
+		entity := getTestAnalysis()
+		entity.ID = uuid.New()
+
+		document := NewSPDX(entity)
+
+		assert.Equal(t, enums.SPDXVersion, document.SPDXVersion)
+		assert.Equal(t, "my-project", document.Name)
+		assert.Equal(t, "https://horusec.io/spdx/"+entity.ID.String(), document.DocumentNamespace)
+		assert.Equal(t, "2021-12-30T23:59:59Z", document.CreationInfo.Created)
+		assert.Len(t, document.Packages, 2)
+		assert.Equal(t, []Relationship{
+			{SPDXElementID: enums.SPDXDocumentID, RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-Package-1"},
+			{SPDXElementID: enums.SPDXDocumentID, RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-Package-2"},
+		}, document.Relationships)
+	})
+
+	t.Run("should add the cves as security references and annotations", func(t *testing.T) {
+		spdxPackage := NewSPDX(getTestAnalysis()).Packages[0]
+
+		assert.Equal(t, "lodash", spdxPackage.Name)
+		assert.Equal(t, "4.17.20", spdxPackage.VersionInfo)
+		assert.Equal(t, []ExternalRef{
+			{ReferenceCategory: enums.RefCategoryPackage, ReferenceType: "purl", ReferenceLocator: "pkg:npm/lodash@4.17.20"},
+			{ReferenceCategory: enums.RefCategorySecurity, ReferenceType: "advisory",
+				ReferenceLocator: "https://nvd.nist.gov/vuln/detail/CVE-2021-23337"},
+		}, spdxPackage.ExternalRefs)
+		assert.Len(t, spdxPackage.Annotations, 2)
+		assert.Equal(t, "CVE-2021-23337 in_triage: Command Injection", spdxPackage.Annotations[0].Comment)
+	})
+
+	t.Run("should generate a namespace when analysis without id", func(t *testing.T) {
+		document := NewSPDX(&analysis.Analysis{})
+
+		assert.NotEqual(t, "https://horusec.io/spdx/"+uuid.Nil.String(), document.DocumentNamespace)
+		assert.Empty(t, document.Packages)
+	})
+}
+
+func TestSPDXToBytes(t *testing.T) {
+	t.Run("should marshal the document to json", func(t *testing.T) {
+		document := &SPDX{}
+
+		assert.NoError(t, json.Unmarshal(NewSPDX(getTestAnalysis()).ToBytes(), document))
+		assert.Equal(t, "SPDXRef-Package-1", document.Packages[0].SPDXID)
+	})
+}
@@ -19,4 +19,5 @@ const (
 	MessageMustContainNumericCharacter   = "must contain a numeric character"
 	MessageMustContainUppercaseCharacter = "must contain an uppercase character"
 	MessageMustContainLowercaseCharacter = "must contain a lowercase character"
+	MessageMustNotBeNilUUID              = "must not be a nil uuid"
 )
@@ -20,3 +20,5 @@ const (
 	RegexNumericCharacter   = "\\d"
 	RegexEspecialCharacter  = "[!@#$&*-._%=+]"
 )
+
+const CodeNilUUID = "validation_nil_uuid"
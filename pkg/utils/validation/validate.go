@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
 
 	"github.com/Fotkurz/horusec-devkit/pkg/enums/auth"
 	"github.com/Fotkurz/horusec-devkit/pkg/utils/validation/enums"
@@ -63,3 +64,26 @@ func PasswordValidationRules() []validation.Rule {
 			Error(enums.MessageMustContainEspecialCharacter),
 	}
 }
+
+// NotNilUUIDRule returns a rule failing when the value is uuid.Nil, which is neither considered empty by
+// validation.Required nor compared by validation.NotIn, since uuid.UUID is validated using its string value.
+func NotNilUUIDRule() validation.Rule {
+	return validation.By(func(value interface{}) error {
+		if id, ok := value.(uuid.UUID); ok && id == uuid.Nil {
+			return validation.NewError(enums.CodeNilUUID, enums.MessageMustNotBeNilUUID)
+		}
+
+		return nil
+	})
+}
+
+// InValuesRule returns a rule failing when the value is not one of the enum values, like severities.Values(). As the
+// validation.In rule, empty values are valid, so it should be used together with validation.Required when needed.
+func InValuesRule[T any](values []T) validation.Rule {
+	elements := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, value)
+	}
+
+	return validation.In(elements...)
+}
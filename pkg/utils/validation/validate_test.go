@@ -18,9 +18,11 @@ import (
 	"testing"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Fotkurz/horusec-devkit/pkg/enums/auth"
+	"github.com/Fotkurz/horusec-devkit/pkg/enums/severities"
 	"github.com/Fotkurz/horusec-devkit/pkg/utils/validation/enums"
 )
 
@@ -79,3 +81,26 @@ func TestPasswordValidationRules(t *testing.T) {
 		assert.Equal(t, err.Error(), enums.MessageMustContainEspecialCharacter)
 	})
 }
+
+func TestNotNilUUIDRule(t *testing.T) {
+	t.Run("should return no error when valid uuid", func(t *testing.T) {
+		assert.NoError(t, validation.Validate(uuid.New(), NotNilUUIDRule()))
+	})
+
+	t.Run("should return error when nil uuid", func(t *testing.T) {
+		err := validation.Validate(uuid.Nil, NotNilUUIDRule())
+
+		assert.Error(t, err)
+		assert.Equal(t, enums.MessageMustNotBeNilUUID, err.Error())
+	})
+}
+
+func TestInValuesRule(t *testing.T) {
+	t.Run("should return no error when the value is one of the values", func(t *testing.T) {
+		assert.NoError(t, validation.Validate(severities.High, InValuesRule(severities.Values())))
+	})
+
+	t.Run("should return error when the value is not one of the values", func(t *testing.T) {
+		assert.Error(t, validation.Validate(severities.Severity("test"), InValuesRule(severities.Values())))
+	})
+}